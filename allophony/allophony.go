@@ -0,0 +1,166 @@
+// Package allophony turns the phonemes of a word into the phones it is
+// actually pronounced with, using ordered context rules in the rule format of
+// the soundchange package.
+package allophony
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/soundchange"
+)
+
+// Phones returns an Alphabet of phones, one Letter per symbol of the default
+// IPA feature table along with long and nasalized vowels and aspirated
+// voiceless stops and affricates. Each Letter is spelled with its IPA symbol
+// and belongs to the Class 'V' if it is syllabic or 'C' otherwise. Extra phones
+// are added after the generated ones.
+func Phones(extra ...alphabet.Letter) alphabet.Alphabet {
+	letters := []alphabet.Letter{}
+	seen := []alphabet.Features{}
+	add := func(symbol string) {
+		features, ok := alphabet.IPAFeatures(symbol)
+		if !ok {
+			return
+		}
+		for _, other := range seen {
+			if features.Equal(other) {
+				return
+			}
+		}
+		seen = append(seen, features)
+		class := alphabet.Class('C')
+		if features[alphabet.Syllabic] {
+			class = 'V'
+		}
+		letters = append(letters, alphabet.WithIPA(alphabet.NewLetter(symbol, symbol, class), symbol))
+	}
+
+	symbols := alphabet.IPASymbols()
+	for _, symbol := range symbols {
+		add(symbol)
+	}
+	for _, symbol := range symbols {
+		features, _ := alphabet.IPAFeatures(symbol)
+		switch {
+		case features[alphabet.Syllabic]:
+			add(symbol + "ː")
+			add(symbol + "\u0303")
+		case features[alphabet.Consonantal] && !features[alphabet.Voice] && !features[alphabet.Continuant]:
+			add(symbol + "ʰ")
+		}
+	}
+	return alphabet.New(append(letters, extra...))
+}
+
+// Transcriber transcribes words written in a phonemic Alphabet.
+type Transcriber struct {
+	phonemes alphabet.Alphabet
+	phones   alphabet.Alphabet
+	rules    *soundchange.Applier
+	// byIPA maps the IPA of each phone to the phone
+	byIPA map[string]alphabet.Letter
+}
+
+// New makes a Transcriber for words in the phonemes Alphabet, whose Letters
+// must have IPA values. Rules are written in the phones Alphabet, such as one
+// made by Phones, and applied in order to the phones of each phoneme.
+func New(phonemes, phones alphabet.Alphabet, rules ...string) (*Transcriber, error) {
+	t := &Transcriber{phonemes: phonemes, phones: phones, byIPA: map[string]alphabet.Letter{}}
+	for _, phone := range phones.GetLetters().ToSlice() {
+		if _, ok := t.byIPA[phone.IPA()]; !ok && phone.IPA() != "" {
+			t.byIPA[phone.IPA()] = phone
+		}
+	}
+
+	parsed := make([]soundchange.Rule, len(rules))
+	for i, rule := range rules {
+		var err error
+		if parsed[i], err = soundchange.ParseRule(phones, rule); err != nil {
+			return nil, err
+		}
+	}
+	t.rules = soundchange.New(phones, parsed...)
+	return t, nil
+}
+
+// Error is returned for a phoneme with no IPA value or no matching phone.
+type Error struct {
+	Word   string
+	Offset int
+	Text   string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("allophony: cannot transcribe %q at byte offset %d of %q", e.Text, e.Offset, e.Word)
+}
+
+// Broad returns the phonemic transcription of a word: the IPA of each of its
+// Letters.
+func (t *Transcriber) Broad(word string) (string, error) {
+	letters, err := t.tokenize(word)
+	if err != nil {
+		return "", err
+	}
+	for i, segment := range t.phonemes.Segment(word) {
+		if letters[i].IPA() == "" {
+			return "", &Error{Word: word, Offset: segment.Offset, Text: segment.Text}
+		}
+	}
+	return spell(letters), nil
+}
+
+// Narrow returns the phonetic transcription of a word after the rules, along
+// with the rules that changed it.
+func (t *Transcriber) Narrow(word string) (string, []soundchange.Step, error) {
+	phones, err := t.phonesOf(word)
+	if err != nil {
+		return "", nil, err
+	}
+	phones, steps := t.rules.ApplyLetters(phones)
+	return spell(phones), steps, nil
+}
+
+// phonesOf returns the phone of each phoneme of a word, found by IPA value, or
+// by Features if no phone has the same IPA.
+func (t *Transcriber) phonesOf(word string) ([]alphabet.Letter, error) {
+	letters, err := t.tokenize(word)
+	if err != nil {
+		return nil, err
+	}
+
+	phones := make([]alphabet.Letter, len(letters))
+	for i, segment := range t.phonemes.Segment(word) {
+		phone, ok := t.byIPA[letters[i].IPA()]
+		if features := letters[i].Features(); !ok && len(features) > 0 {
+			if matches := t.phones.GetLettersByFeatures(features).ToSlice(); len(matches) > 0 {
+				phone, ok = matches[0], true
+			}
+		}
+		if !ok {
+			return nil, &Error{Word: word, Offset: segment.Offset, Text: segment.Text}
+		}
+		phones[i] = phone
+	}
+	return phones, nil
+}
+
+func (t *Transcriber) tokenize(word string) ([]alphabet.Letter, error) {
+	letters, err := t.phonemes.Tokenize(word)
+	var ambiguous *alphabet.AmbiguousError
+	if err != nil && !errors.As(err, &ambiguous) {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// spell writes Letters as IPA.
+func spell(letters []alphabet.Letter) string {
+	var b strings.Builder
+	for _, letter := range letters {
+		b.WriteString(letter.IPA())
+	}
+	return b.String()
+}
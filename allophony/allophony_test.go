@@ -0,0 +1,80 @@
+package allophony
+
+import (
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+func TestTranscribe(t *testing.T) {
+	letter := func(lower, ipa string, classes ...alphabet.Class) alphabet.Letter {
+		return alphabet.WithIPA(alphabet.NewLetter(lower, lower, classes...), ipa)
+	}
+	phonemes := alphabet.New([]alphabet.Letter{
+		letter("a", "a", 'V'),
+		letter("i", "i", 'V'),
+		letter("u", "u", 'V'),
+		letter("p", "p", 'C'),
+		letter("b", "b", 'C'),
+		letter("t", "t", 'C'),
+		letter("d", "d", 'C'),
+		letter("k", "k", 'C'),
+		letter("g", "g", 'C'),
+		letter("m", "m", 'C'),
+		letter("n", "n", 'C'),
+		letter("s", "s", 'C'),
+		letter("c", "tʃ", 'C'),
+		alphabet.WithFeatures(alphabet.NewLetter("x", "x", 'C'), alphabet.Features{}),
+	})
+
+	transcriber, err := New(phonemes, Phones(),
+		"b, d, g > β, ð, ɣ / V_V",
+		"n > m / _[+labial]",
+		"n > ŋ / _[+dorsal -syllabic]",
+		"V > [+long] / _[+voice +consonantal]",
+		"p, t, k > pʰ, tʰ, kʰ / #_",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		Word   string
+		Broad  string
+		Narrow string
+	}{
+		{"aba", "aba", "aːβa"},
+		{"anpa", "anpa", "aːmpa"},
+		{"tanka", "tanka", "tʰaːŋka"},
+		{"pitsa", "pitsa", "pʰitsa"},
+		{"cuda", "tʃuda", "tʃuːða"},
+		{"kus", "kus", "kʰus"},
+	} {
+		broad, err := transcriber.Broad(testCase.Word)
+		if err != nil || broad != testCase.Broad {
+			t.Logf("Expected broad transcription of %q to be %q; got %q (%v)\n", testCase.Word, testCase.Broad, broad, err)
+			t.Fail()
+		}
+		narrow, steps, err := transcriber.Narrow(testCase.Word)
+		if err != nil || narrow != testCase.Narrow {
+			t.Logf("Expected narrow transcription of %q to be %q; got %q (%v, %v)\n", testCase.Word, testCase.Narrow, narrow, steps, err)
+			t.Fail()
+		}
+	}
+
+	for _, word := range []string{"xa", "aqa"} {
+		if _, _, err := transcriber.Narrow(word); err == nil {
+			t.Logf("Expected an error transcribing %q\n", word)
+			t.Fail()
+		}
+		if _, err := transcriber.Broad(word); err == nil {
+			t.Logf("Expected an error transcribing %q broadly\n", word)
+			t.Fail()
+		}
+	}
+
+	if _, err := New(phonemes, Phones(), "p > ж"); err == nil {
+		t.Log("Expected an error for a rule with an unknown phone")
+		t.Fail()
+	}
+}
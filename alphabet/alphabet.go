@@ -3,33 +3,137 @@ package alphabet
 import "github.com/jack-reeser/conlang/common"
 
 // Alphabet represents a collection of Letters
+//
+// Alphabet is meant to be implemented by New only. Every method after
+// GetClasses was added after the first release, so Alphabets implemented
+// outside this package must add them.
 type Alphabet interface {
 	// GetLetters returns all Letters
 	GetLetters() common.Collection[Letter]
-	// GetLettersByClass returns all Letters that match the given Class
+	// GetLettersByClass returns all Letters that match the given Class. Use
+	// ClassNamed to find a Class by its name.
 	GetLettersByClass(Class) common.Collection[Letter]
-	// GetClasses returns a slice of all unique Classes
+	// GetClasses returns a slice of all unique Classes, including Classes
+	// declared with WithClasses that have no Letters.
 	GetClasses() common.Collection[Class]
+	// HasClass returns true if the Letter belongs to the Class.
+	HasClass(Letter, Class) bool
+	// Query returns all Letters matching a class expression such as "C & !N"
+	// or "front-vowel | round". See ClassExpr for the syntax.
+	Query(string) (common.Collection[Letter], error)
+	// GetLettersByFeatures returns all Letters whose Features include every
+	// value in the given bundle, such as [+voice -continuant].
+	GetLettersByFeatures(Features) common.Collection[Letter]
+	// Modify returns the Letter that differs from the given Letter by the
+	// given changes, such as the voiced counterpart for [+voice]. If no
+	// Letter differs by exactly those changes, the closest Letter with the
+	// changed values is returned. It returns false if no Letter has them.
+	Modify(Letter, Features) (Letter, bool)
+	// Shorthands returns the single rune Classes named with WithShorthand
+	// along with their names.
+	Shorthands() map[rune]ClassName
+	// Segment splits a string into Letters using longest-match, backing off
+	// to a shorter Letter where the longest one would leave the rest unsplit.
+	// Text that does not belong to the Alphabet is returned one rune at a time
+	// with a nil Letter.
+	Segment(string) []Segment
+	// Tokenize splits a string into Letters like Segment. It returns an
+	// *UnknownError if any part of the string is not in the Alphabet, and an
+	// *AmbiguousError along with the Letters if the string could also be split
+	// another way.
+	Tokenize(string) ([]Letter, error)
+	// Order returns the position of a Letter in the Alphabet's collation order,
+	// which is the order the Letters were given to New. It returns -1 if the
+	// Letter is not in the Alphabet.
+	Order(Letter) int
+	// Compare compares two words letter-by-letter in the Alphabet's order,
+	// using diacritics and then case to break ties.
+	Compare(a, b string) int
+	// SortKey returns the collation key Compare uses for a word.
+	SortKey(string) SortKey
+	// Weight returns how often a Letter should be chosen relative to the other
+	// Letters of a Class. It is the weight given with WithWeight for the Class
+	// when there is one, and otherwise follows Zipf's law by the Letter's rank
+	// within the Class, so that earlier Letters are more common.
+	Weight(Letter, Class) float64
+	// GetRandomLetter returns a Letter of the Class chosen by Weight, or nil if
+	// the Class has no Letters.
+	GetRandomLetter(Class) Letter
+	// GetRandomLetterAt is like GetRandomLetter, but only chooses from Letters
+	// whose Positions allow them at the given Position.
+	GetRandomLetterAt(Class, Position) Letter
+	// Metadata returns free-form information about the Alphabet, such as its
+	// name or the language it belongs to.
+	Metadata() map[string]string
+	// Validate checks the Alphabet for duplicate Letters, case collisions,
+	// ambiguous segmentations, empty Classes, unclassified Letters and shared
+	// native forms. It returns nil if no problems were found.
+	Validate() []Diagnostic
+}
+
+// Option configures an Alphabet made by New.
+type Option func(*basicAlphabet)
+
+// WithMetadata attaches free-form metadata to an Alphabet.
+func WithMetadata(metadata map[string]string) Option {
+	return func(b *basicAlphabet) {
+		for key, value := range metadata {
+			b.metadata[key] = value
+		}
+	}
+}
+
+// WithClasses declares the Classes an Alphabet is expected to use, so that
+// Validate can report the ones no Letter belongs to.
+func WithClasses(classes ...Class) Option {
+	return func(b *basicAlphabet) {
+		b.classes = append(b.classes, classes...)
+	}
+}
+
+// WithShorthand names the single rune Class of shorthand, so that class
+// expressions and definition files can refer to the Class 'N' as "nasal" while
+// patterns like "CVN" use the rune. Each Alphabet keeps its own names.
+func WithShorthand(shorthand rune, name ClassName) Option {
+	return func(b *basicAlphabet) {
+		b.shorthands[shorthand] = name
+	}
 }
 
 // New takes a list of Letters and returns an Alphabet
-func New(letters []Letter) Alphabet {
-	return basicAlphabet{common.CollectionFrom[Letter](letters)}
+func New(letters []Letter, options ...Option) Alphabet {
+	alphabet := basicAlphabet{
+		Collection: common.CollectionFrom[Letter](letters),
+		index:      newLetterIndex(letters),
+		metadata:   map[string]string{},
+		shorthands: map[rune]ClassName{},
+	}
+	for _, option := range options {
+		option(&alphabet)
+	}
+	return alphabet
 }
 
 type basicAlphabet struct {
 	common.Collection[Letter]
+	index      *letterIndex
+	metadata   map[string]string
+	classes    []Class
+	shorthands map[rune]ClassName
 }
 
-func (b basicAlphabet) GetLetters() common.Collection[Letter] { return b }
+func (b basicAlphabet) GetLetters() common.Collection[Letter] { return b.Collection }
 func (b basicAlphabet) GetLettersByClass(c Class) common.Collection[Letter] {
 	return b.Select(
 		func(l Letter) bool {
-			return l.IsClass(c)
+			return b.HasClass(l, c)
 		})
 }
 func (b basicAlphabet) GetClasses() common.Collection[Class] {
 	alphabetClassSet := map[Class]bool{}
+	for _, class := range b.classes {
+		alphabetClassSet[class] = true
+	}
 	for _, letter := range b.ToSlice() {
 		for class := range letter.GetClassMap() {
 			alphabetClassSet[class] = true
@@ -37,3 +141,49 @@ func (b basicAlphabet) GetClasses() common.Collection[Class] {
 	}
 	return common.CollectionFrom[Class](alphabetClassSet)
 }
+func (b basicAlphabet) HasClass(l Letter, c Class) bool     { return l.IsClass(c) }
+func (b basicAlphabet) Shorthands() map[rune]ClassName      { return b.shorthands }
+func (b basicAlphabet) Segment(s string) []Segment          { return b.index.segment(s) }
+func (b basicAlphabet) Tokenize(s string) ([]Letter, error) { return b.index.tokenize(s) }
+func (b basicAlphabet) Order(l Letter) int {
+	if rank, ok := b.index.order[l.Lower()]; ok {
+		return rank
+	}
+	return -1
+}
+func (b basicAlphabet) Weight(l Letter, c Class) float64 {
+	letters := b.GetLettersByClass(c).ToSlice()
+	for rank, letter := range letters {
+		if letter.Lower() == l.Lower() {
+			return b.weights(c, letters)[rank]
+		}
+	}
+	return 0
+}
+func (b basicAlphabet) GetRandomLetter(c Class) Letter { return b.GetRandomLetterAt(c, Anywhere) }
+func (b basicAlphabet) GetRandomLetterAt(c Class, p Position) Letter {
+	letters := b.GetLettersByClass(c).ToList()
+	weights := b.weights(c, letters)
+	rank := map[string]int{}
+	for i, letter := range letters {
+		rank[letter.Lower()] = i
+		if !letter.Positions().Allows(p) {
+			weights[i] = 0
+		}
+	}
+	return letters.GetWeightedRandom(func(l Letter) float64 { return weights[rank[l.Lower()]] })
+}
+
+// weights returns the Weight of each of the Letters of a Class, in order.
+func (b basicAlphabet) weights(c Class, letters []Letter) []float64 {
+	weights := common.ZipfWeights(len(letters), 1)
+	for i, letter := range letters {
+		if weight, ok := letter.Weights()[c]; ok {
+			weights[i] = weight
+		}
+	}
+	return weights
+}
+func (b basicAlphabet) Compare(x, y string) int     { return NewCollator(b, Tertiary).Compare(x, y) }
+func (b basicAlphabet) SortKey(s string) SortKey    { return NewCollator(b, Tertiary).SortKey(s) }
+func (b basicAlphabet) Metadata() map[string]string { return b.metadata }
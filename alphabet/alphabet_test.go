@@ -0,0 +1,333 @@
+package alphabet
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jack-reeser/conlang/common"
+)
+
+func testAlphabet() Alphabet {
+	return New([]Letter{
+		NewLetter("A", "a", 'V'),
+		NewLetter("C", "c", 'C'),
+		NewLetter("CH", "ch", 'C'),
+		NewLetter("E", "e", 'V'),
+		NewLetter("H", "h", 'C'),
+		NewLetter("S", "s", 'C'),
+		NewLetter("SH", "sh", 'C'),
+		NewLetter("T", "t", 'C'),
+		NewLetter("TCH", "tch", 'C'),
+	})
+}
+
+func lettersToString(letters []Letter) string {
+	s := make([]string, len(letters))
+	for i, letter := range letters {
+		s[i] = letter.Lower()
+	}
+	return strings.Join(s, ".")
+}
+
+func TestTokenize(t *testing.T) {
+	a := testAlphabet()
+
+	for _, testCase := range []struct {
+		Input  string
+		Output string
+	}{
+		{"shatcha", "sh.a.tch.a"},
+		{"Shatcha", "sh.a.tch.a"},
+		{"CHECH", "ch.e.ch"},
+		{"", ""},
+	} {
+		letters, err := a.Tokenize(testCase.Input)
+		var ambiguous *AmbiguousError
+		if err != nil && !errors.As(err, &ambiguous) {
+			t.Logf("Expected %q to tokenize; got %v\n", testCase.Input, err)
+			t.Fail()
+			continue
+		}
+		if output := lettersToString(letters); output != testCase.Output {
+			t.Logf("Expected %q to tokenize as %s; got %s\n", testCase.Input, testCase.Output, output)
+			t.Fail()
+		}
+	}
+
+	var unknown *UnknownError
+	if _, err := a.Tokenize("shaxa"); !errors.As(err, &unknown) {
+		t.Logf("Expected an UnknownError; got %v\n", err)
+		t.Fail()
+	} else if unknown.Offset != 3 || unknown.Char != 'x' {
+		t.Logf("Expected unknown 'x' at offset 3; got %q at %d\n", unknown.Char, unknown.Offset)
+		t.Fail()
+	}
+
+	var ambiguous *AmbiguousError
+	if letters, err := a.Tokenize("asha"); !errors.As(err, &ambiguous) {
+		t.Logf("Expected an AmbiguousError; got %v\n", err)
+		t.Fail()
+	} else {
+		if ambiguous.Offset != 1 || ambiguous.Alternative.Lower() != "s" {
+			t.Logf("Expected ambiguity at offset 1 with \"s\"; got %q at %d\n", ambiguous.Alternative, ambiguous.Offset)
+			t.Fail()
+		}
+		if output := lettersToString(letters); output != "a.sh.a" {
+			t.Logf("Expected longest-match letters a.sh.a; got %s\n", output)
+			t.Fail()
+		}
+	}
+
+	// longest-match backs off to a shorter Letter when the rest cannot be split
+	backtracking := New([]Letter{NewLetter("AB", "ab"), NewLetter("A", "a"), NewLetter("BC", "bc")})
+	if letters, err := backtracking.Tokenize("abc"); err != nil || lettersToString(letters) != "a.bc" {
+		t.Logf("Expected \"abc\" to tokenize as a.bc; got %s (%v)\n", lettersToString(letters), err)
+		t.Fail()
+	}
+	if segments := backtracking.Segment("abc ab"); len(segments) != 4 || segments[1].Text != "bc" || segments[3].Text != "ab" {
+		t.Logf("Expected \"abc ab\" to segment as a.bc. .ab; got %v\n", segments)
+		t.Fail()
+	}
+
+	if segments := a.Segment("a sh"); len(segments) != 3 || segments[1].Letter != nil || segments[2].Offset != 2 {
+		t.Logf("Expected 3 segments with an unknown space; got %v\n", segments)
+		t.Fail()
+	}
+}
+
+func TestCollation(t *testing.T) {
+	a := New([]Letter{
+		NewLetter("A", "a", 'V'),
+		NewLetter("E", "e", 'V'),
+		NewLetter("C", "c", 'C'),
+		NewLetter("CH", "ch", 'C'),
+		NewLetter("D", "d", 'C'),
+	})
+
+	input := common.List[string]{"da", "cha", "Ca", "ca\u0301", "ca", "ced", "ech", "ad"}
+	solution := []string{"ad", "ech", "ca", "Ca", "ca\u0301", "ced", "cha", "da"}
+
+	if sorted := input.ToSortedList(a.Compare); !slices.Equal(solution, sorted) {
+		t.Logf("Expected %q to equal %q\n", []string(sorted), solution)
+		t.Fail()
+	}
+
+	primary := NewCollator(a, Primary)
+	if primary.Compare("Ca\u0301", "ca") != 0 {
+		t.Log("Expected primary strength to ignore case and diacritics")
+		t.Fail()
+	}
+	if secondary := NewCollator(a, Secondary); secondary.Compare("Ca", "ca") != 0 || secondary.Compare("ca", "ca\u0301") >= 0 {
+		t.Log("Expected secondary strength to ignore case but not diacritics")
+		t.Fail()
+	}
+
+	// precomposed and decomposed diacritics sort the same
+	if a.Compare("c\u00e1", "ca\u0301") != 0 || primary.Compare("c\u00e1", "ca") != 0 || a.Compare("c\u00e1", "ced") >= 0 {
+		t.Log("Expected precomposed letters to sort as their decomposed forms")
+		t.Fail()
+	}
+	accented := New([]Letter{NewLetter("A", "a"), NewLetter("\u00c1", "\u00e1"), NewLetter("B", "b")})
+	if accented.Compare("\u00e1", "b") >= 0 || NewCollator(accented, Primary).Compare("\u00e1", "a") == 0 {
+		t.Log("Expected a precomposed Letter to keep its own place")
+		t.Fail()
+	}
+}
+
+func TestCase(t *testing.T) {
+	a := New([]Letter{
+		NewLetter("A", "a"),
+		NewLetter("Ll", "ll"),
+		NewLetter("M", "m"),
+		NewLetter("I", "ı"),
+		NewLetter("İ", "i"),
+	})
+
+	for _, testCase := range []struct {
+		Input string
+		Upper string
+		Lower string
+		Title string
+	}{
+		{"llama", "LLAMA", "llama", "Llama"},
+		{"LLAMA ñu", "LLAMA ÑU", "llama ñu", "Llama Ñu"},
+		{"mıli-lla", "MILİ-LLA", "mıli-lla", "Mıli-Lla"},
+	} {
+		if upper := ToUpper(a, testCase.Input); upper != testCase.Upper {
+			t.Logf("Expected ToUpper(%q) to equal %q; got %q\n", testCase.Input, testCase.Upper, upper)
+			t.Fail()
+		}
+		if lower := ToLower(a, testCase.Input); lower != testCase.Lower {
+			t.Logf("Expected ToLower(%q) to equal %q; got %q\n", testCase.Input, testCase.Lower, lower)
+			t.Fail()
+		}
+		if title := ToTitle(a, testCase.Input); title != testCase.Title {
+			t.Logf("Expected ToTitle(%q) to equal %q; got %q\n", testCase.Input, testCase.Title, title)
+			t.Fail()
+		}
+	}
+
+	if letters, err := a.Tokenize("LLAMA"); err != nil || len(letters) != 4 {
+		t.Logf("Expected \"LLAMA\" to tokenize into 4 letters; got %d (%v)\n", len(letters), err)
+		t.Fail()
+	}
+}
+
+func TestNamedClasses(t *testing.T) {
+	a := New([]Letter{
+		NewLetter("A", "a", 'V'),
+		NewLetter("I", "i", 'V', 'F'),
+		NewLetter("M", "m", 'C', 'N'),
+		NewLetter("N", "n", 'C', 'N'),
+		NewLetter("T", "t", 'C', 'P'),
+	}, WithShorthand('N', "nasal"), WithShorthand('F', "front-vowel"))
+	other := New(nil, WithShorthand('N', "nominal"))
+
+	for _, testCase := range []struct {
+		Alphabet Alphabet
+		Name     ClassName
+		Class    Class
+		OK       bool
+	}{
+		{a, "nasal", 'N', true},
+		{a, "front-vowel", 'F', true},
+		{a, "N", 'N', true},
+		{a, "C", 'C', true},
+		{a, "stop", 0, false},
+		{other, "nominal", 'N', true},
+		{other, "nasal", 0, false},
+		{a, "", 0, false},
+	} {
+		if class, ok := ClassNamed(testCase.Alphabet, testCase.Name); class != testCase.Class || ok != testCase.OK {
+			t.Logf("Expected %q to name %q (%t); got %q (%t)\n", testCase.Name, testCase.Class, testCase.OK, class, ok)
+			t.Fail()
+		}
+	}
+
+	if NameOf(a, 'N') != "nasal" || NameOf(other, 'N') != "nominal" || NameOf(a, 'C') != "C" {
+		t.Logf("Expected each alphabet to name its own classes; got %q, %q and %q\n", NameOf(a, 'N'), NameOf(other, 'N'), NameOf(a, 'C'))
+		t.Fail()
+	}
+	if letters, err := a.Query("nasal | front-vowel"); err != nil || letters.Len() != 3 {
+		t.Logf("Expected 3 nasals and front vowels; got %v (%v)\n", letters, err)
+		t.Fail()
+	}
+	if letters, err := a.Query("stop"); err != nil || letters.Len() != 0 {
+		t.Logf("Expected an undeclared name to match no letters; got %v (%v)\n", letters, err)
+		t.Fail()
+	}
+}
+
+func TestWeights(t *testing.T) {
+	a := New([]Letter{
+		NewLetter("A", "a", 'V'),
+		NewLetter("E", "e", 'V'),
+		NewLetter("I", "i", 'V'),
+		WithWeight(NewLetter("N", "n", 'C', 'N'), 'N', 3),
+		WithWeight(NewLetter("M", "m", 'C', 'N'), 'N', 1),
+		NewLetter("X", "x", 'C'),
+	})
+	letter := func(s string) Letter {
+		letters, _ := a.Tokenize(s)
+		return letters[0]
+	}
+
+	for _, testCase := range []struct {
+		Letter string
+		Class  Class
+		Weight float64
+	}{
+		{"a", 'V', 1},
+		{"e", 'V', 0.5},
+		{"i", 'V', 1.0 / 3},
+		{"n", 'N', 3},
+		{"m", 'N', 1},
+		{"x", 'C', 1.0 / 3},
+		{"x", 'N', 0},
+	} {
+		if weight := a.Weight(letter(testCase.Letter), testCase.Class); weight != testCase.Weight {
+			t.Logf("Expected %q to weigh %v in class %q; got %v\n", testCase.Letter, testCase.Weight, testCase.Class, weight)
+			t.Fail()
+		}
+	}
+
+	counts := map[string]int{}
+	for i := 0; i < 2000; i++ {
+		counts[a.GetRandomLetter('N').Lower()]++
+	}
+	if counts["n"] < 2*counts["m"] || counts["m"] == 0 {
+		t.Logf("Expected about three times as many \"n\" as \"m\"; got %v\n", counts)
+		t.Fail()
+	}
+	if letter := a.GetRandomLetter('F'); letter != nil {
+		t.Logf("Expected no letter from an empty class; got %v\n", letter)
+		t.Fail()
+	}
+}
+
+func TestXSAMPA(t *testing.T) {
+	for _, testCase := range []struct {
+		Letter Letter
+		XSAMPA string
+	}{
+		{NewLetter("A", "a"), ""},
+		{WithIPA(NewLetter("Ŋ", "ŋ"), "ŋ"), "N"},
+		{WithIPA(NewLetter("Sh", "sh"), "ʃ"), "S"},
+		{WithXSAMPA(WithIPA(NewLetter("'", "'"), "ʔ"), "?\\"), "?\\"},
+		{WithXSAMPA(NewLetter("R", "r"), "r\\"), "r\\"},
+	} {
+		if xsampa := testCase.Letter.XSAMPA(); xsampa != testCase.XSAMPA {
+			t.Logf("Expected %q to be %q in X-SAMPA; got %q\n", testCase.Letter, testCase.XSAMPA, xsampa)
+			t.Fail()
+		}
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	for _, testCase := range []struct {
+		Composed   string
+		Decomposed string
+	}{
+		{"café", "café"},
+		{"ệ", "ệ"},
+		{"Ångström", "Ångström"},
+		{"abc", "abc"},
+		// only Latin letters are decomposed
+		{"\u03ac", "\u03ac"},
+		{"\u0439", "\u0439"},
+		{"\u03b1\u0301", "\u03b1\u0301"},
+	} {
+		if output := Decompose(testCase.Composed); output != testCase.Decomposed {
+			t.Logf("Expected %q to decompose to %q; got %q\n", testCase.Composed, testCase.Decomposed, output)
+			t.Fail()
+		}
+		if output := Compose(testCase.Decomposed); output != testCase.Composed {
+			t.Logf("Expected %q to compose to %q; got %q\n", testCase.Decomposed, testCase.Composed, output)
+			t.Fail()
+		}
+	}
+}
+
+func TestMark(t *testing.T) {
+	precomposed := New([]Letter{NewLetter("A", "a"), NewLetter("\u00c1", "\u00e1"), NewLetter("O", "o")})
+	decomposed := New([]Letter{NewLetter("A", "a"), NewLetter("A\u0301", "a\u0301")})
+	for _, testCase := range []struct {
+		Alphabet Alphabet
+		Text     string
+		Marked   string
+	}{
+		{precomposed, "a", "\u00e1"},
+		{precomposed, "A", "\u00c1"},
+		{precomposed, "o", "o\u0301"},
+		{decomposed, "A", "A\u0301"},
+		{decomposed, "a", "a\u0301"},
+	} {
+		segment := testCase.Alphabet.Segment(testCase.Text)[0]
+		if marked := Mark(testCase.Alphabet, segment, "\u0301"); marked != testCase.Marked {
+			t.Logf("Expected %q to be marked %q; got %q\n", testCase.Text, testCase.Marked, marked)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package alphabet
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ToUpper converts s to uppercase using the upper form of each Letter in the
+// Alphabet. Multigraphs are fully uppercased, so "ll" becomes "LL" even if the
+// Letter was made with an upper form of "Ll". Text outside the Alphabet uses
+// Unicode casing.
+func ToUpper(a Alphabet, s string) string {
+	var b strings.Builder
+	for _, segment := range a.Segment(s) {
+		if segment.Letter == nil {
+			b.WriteString(strings.ToUpper(segment.Text))
+		} else {
+			b.WriteString(allCaps(segment.Letter))
+		}
+	}
+	return b.String()
+}
+
+// ToLower converts s to lowercase using the lower form of each Letter in the
+// Alphabet. Text outside the Alphabet uses Unicode casing.
+func ToLower(a Alphabet, s string) string {
+	var b strings.Builder
+	for _, segment := range a.Segment(s) {
+		if segment.Letter == nil {
+			b.WriteString(strings.ToLower(segment.Text))
+		} else {
+			b.WriteString(segment.Letter.Lower())
+		}
+	}
+	return b.String()
+}
+
+// ToTitle converts s to title case, where the first Letter of every word uses
+// its titlecase form ("Ll" for "ll") and every other Letter is lowercased. A
+// word starts after any text that is neither a Letter of the Alphabet nor a
+// Unicode letter or mark, so "la-llama ñu" becomes "La-Llama Ñu".
+func ToTitle(a Alphabet, s string) string {
+	var b strings.Builder
+	wordStart := true
+	for _, segment := range a.Segment(s) {
+		if segment.Letter != nil {
+			if wordStart {
+				b.WriteString(title(segment.Letter))
+			} else {
+				b.WriteString(segment.Letter.Lower())
+			}
+			wordStart = false
+			continue
+		}
+
+		char := []rune(segment.Text)[0]
+		switch {
+		case !unicode.IsLetter(char) && !unicode.IsMark(char):
+			b.WriteString(segment.Text)
+			wordStart = true
+		case wordStart:
+			b.WriteRune(unicode.ToTitle(char))
+			wordStart = false
+		default:
+			b.WriteRune(unicode.ToLower(char))
+		}
+	}
+	return b.String()
+}
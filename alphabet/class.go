@@ -1,19 +1,51 @@
 package alphabet
 
-// Class is a rune that represents a distinct class of letters.
+import "unicode/utf8"
+
+// Class is a rune that represents a distinct class of letters, such as 'C' or
+// 'V'. An Alphabet may give a Class a longer name, such as "nasal", with
+// WithShorthand.
 type Class rune
 
-// StringToClasses converts a string into a slice of Classes. If the given string
-// has a length of zero, an empty slice is returned.
-func StringToClasses(s string) []Class {
-	if len(s) <= 0 {
-		return []Class{}
+// ClassName is the name of a Class, such as "nasal" or "front-vowel". A single
+// rune name, like "C", names the Class of that rune.
+type ClassName string
+
+// ClassNamed returns the Class an Alphabet gives the name: the shorthand
+// declared for it with WithShorthand, or the Class of a single rune name. It
+// returns false if the name is longer than one rune and was not declared.
+func ClassNamed(a Alphabet, name ClassName) (Class, bool) {
+	for shorthand, n := range a.Shorthands() {
+		if n == name {
+			return Class(shorthand), true
+		}
 	}
+	if r, size := utf8.DecodeRuneInString(string(name)); size > 0 && size == len(name) {
+		return Class(r), true
+	}
+	return 0, false
+}
 
-	classes := make([]Class, len(s))
-	for i, char := range s {
-		classes[i] = Class(char)
+// NameOf returns the name an Alphabet gives a Class with WithShorthand, or the
+// Class's rune if it has none.
+func NameOf(a Alphabet, c Class) ClassName {
+	if name, ok := a.Shorthands()[rune(c)]; ok {
+		return name
 	}
+	return ClassName(rune(c))
+}
 
+// StringToClasses converts a string into a slice of single rune Classes, one
+// per rune. If the given string has a length of zero, an empty slice is
+// returned.
+func StringToClasses(s string) []Class {
+	classes := make([]Class, 0, len(s))
+	for _, char := range s {
+		classes = append(classes, Class(char))
+	}
 	return classes
 }
+
+// Boundary is a Class that no Letter belongs to. Rules that match Letters by the
+// Class of their neighbours use it to match the edge of a word.
+const Boundary = Class('#')
@@ -0,0 +1,102 @@
+package alphabet
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Strength is the number of levels a Collator compares strings at.
+type Strength int
+
+const (
+	// Primary compares strings by the order of their Letters only.
+	Primary Strength = iota + 1
+	// Secondary breaks Primary ties using diacritics (combining marks that
+	// are not part of a Letter).
+	Secondary
+	// Tertiary breaks Secondary ties using case, with lowercase first.
+	Tertiary
+)
+
+// SortKey is a precomputed collation key. Two SortKeys made by the same
+// Collator compare the same way as the strings they were made from.
+type SortKey []int
+
+// Compare returns -1, 0 or 1 as k sorts before, with, or after other.
+func (k SortKey) Compare(other SortKey) int { return slices.Compare(k, other) }
+
+// Collator sorts words letter-by-letter in the order of an Alphabet. Text that
+// is not part of the Alphabet sorts after every Letter, in Unicode order.
+type Collator struct {
+	Alphabet Alphabet
+	Strength Strength
+}
+
+// NewCollator returns a Collator for the given Alphabet and Strength.
+func NewCollator(a Alphabet, strength Strength) Collator {
+	return Collator{Alphabet: a, Strength: strength}
+}
+
+// Compare returns -1, 0 or 1 as a sorts before, with, or after b. It may be
+// passed directly to common.List.ToSortedList.
+func (c Collator) Compare(a, b string) int {
+	return c.SortKey(a).Compare(c.SortKey(b))
+}
+
+// SortKey returns the collation key for s. Precomposed letters that are not
+// Letters of the Alphabet are decomposed first, so that "\u00e9" sorts as "e"
+// with a secondary difference whichever way it is written.
+func (c Collator) SortKey(s string) SortKey {
+	var primary, secondary, tertiary []int
+	unknownBase := c.Alphabet.GetLetters().Len() + 1
+
+	for _, segment := range c.Alphabet.Segment(c.decompose(s)) {
+		if segment.Letter == nil {
+			char := []rune(segment.Text)[0]
+			// diacritics attach to whatever came before them
+			if unicode.Is(unicode.Mn, char) && len(primary) > 0 {
+				secondary = append(secondary, int(char))
+				continue
+			}
+			primary = append(primary, unknownBase+int(unicode.ToLower(char)))
+			secondary = append(secondary, 1)
+			if unicode.IsUpper(char) {
+				tertiary = append(tertiary, 2)
+			} else {
+				tertiary = append(tertiary, 1)
+			}
+			continue
+		}
+
+		primary = append(primary, c.Alphabet.Order(segment.Letter)+1)
+		secondary = append(secondary, 1)
+		if segment.IsUpper() {
+			tertiary = append(tertiary, 2)
+		} else {
+			tertiary = append(tertiary, 1)
+		}
+	}
+
+	key := SortKey(primary)
+	if c.Strength >= Secondary {
+		key = append(append(key, 0), secondary...)
+	}
+	if c.Strength >= Tertiary {
+		key = append(append(key, 0), tertiary...)
+	}
+	return key
+}
+
+// decompose decomposes the text of s that is not part of the Alphabet.
+func (c Collator) decompose(s string) string {
+	var b strings.Builder
+	for _, segment := range c.Alphabet.Segment(s) {
+		if segment.Letter == nil {
+			b.WriteString(Decompose(segment.Text))
+		} else {
+			b.WriteString(segment.Text)
+		}
+	}
+	return b.String()
+}
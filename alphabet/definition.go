@@ -0,0 +1,260 @@
+package alphabet
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Definition is the serializable form of an Alphabet. Letters are listed in the
+// Alphabet's collation order.
+type Definition struct {
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Classes  []string          `json:"classes,omitempty"`
+	// Shorthands maps single character Classes to their names. Class names
+	// that are longer than one character and have no shorthand are given one
+	// when the Definition is read.
+	Shorthands map[string]string  `json:"shorthands,omitempty"`
+	Letters    []LetterDefinition `json:"letters"`
+	// ClassesLine is the line of the file Classes was read from, if any.
+	ClassesLine int `json:"-"`
+	// ShorthandLines maps shorthands to the lines of the file they were read
+	// from, if any.
+	ShorthandLines map[string]int `json:"-"`
+}
+
+// LetterDefinition is the serializable form of a Letter. Upper may be left
+// empty when it is the same as Lower.
+type LetterDefinition struct {
+	Upper   string   `json:"upper,omitempty"`
+	Lower   string   `json:"lower"`
+	Classes []string `json:"classes,omitempty"`
+	// Features is a feature bundle in the form read by ParseFeatures.
+	Features string `json:"features,omitempty"`
+	IPA      string `json:"ipa,omitempty"`
+	// XSAMPA is set when it differs from the X-SAMPA spelling of IPA.
+	XSAMPA string `json:"xsampa,omitempty"`
+	// Native lists the codepoints of the native form, as in "U+E000".
+	Native string `json:"native,omitempty"`
+	// Glyph is SVG path data for the shape of the Letter.
+	Glyph string `json:"glyph,omitempty"`
+	// Weights maps Class names to the relative frequency of the Letter in
+	// them.
+	Weights map[string]float64 `json:"weights,omitempty"`
+	// Positions names the positions the Letter is restricted to, such as
+	// "initial" or "coda".
+	Positions []string `json:"positions,omitempty"`
+	// Line is the line of the file the Letter was read from, if any.
+	Line int `json:"-"`
+}
+
+// DefinitionError reports an invalid Definition, along with the line it was
+// found on when the Definition was read from a file.
+type DefinitionError struct {
+	Line    int
+	Message string
+}
+
+func (e *DefinitionError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("alphabet: line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("alphabet: %s", e.Message)
+}
+
+// Define returns the Definition of an Alphabet.
+func Define(a Alphabet) Definition {
+	definition := Definition{Metadata: a.Metadata()}
+	for _, class := range a.GetClasses().ToSlice() {
+		definition.Classes = append(definition.Classes, string(NameOf(a, class)))
+	}
+	slices.Sort(definition.Classes)
+	for shorthand, name := range a.Shorthands() {
+		if definition.Shorthands == nil {
+			definition.Shorthands = map[string]string{}
+		}
+		definition.Shorthands[string(shorthand)] = string(name)
+	}
+	for _, letter := range a.GetLetters().ToSlice() {
+		letterDefinition := LetterDefinition{Lower: letter.Lower()}
+		if letter.Upper() != letter.Lower() {
+			letterDefinition.Upper = letter.Upper()
+		}
+		for _, class := range letter.GetClassSlice() {
+			letterDefinition.Classes = append(letterDefinition.Classes, string(NameOf(a, class)))
+		}
+		slices.Sort(letterDefinition.Classes)
+		if len(letter.Features()) > 0 {
+			letterDefinition.Features = letter.Features().String()
+		}
+		letterDefinition.IPA = letter.IPA()
+		if letter.XSAMPA() != XSAMPA(letter.IPA()) {
+			letterDefinition.XSAMPA = letter.XSAMPA()
+		}
+		if letter.Native() != "" {
+			letterDefinition.Native = "U+" + FormatCodepoints(letter.Native(), " U+")
+		}
+		letterDefinition.Glyph = letter.Glyph()
+		if letter.Positions() != Anywhere {
+			letterDefinition.Positions = letter.Positions().Names()
+		}
+		for class, weight := range letter.Weights() {
+			if letterDefinition.Weights == nil {
+				letterDefinition.Weights = map[string]float64{}
+			}
+			letterDefinition.Weights[string(NameOf(a, class))] = weight
+		}
+		definition.Letters = append(definition.Letters, letterDefinition)
+	}
+	return definition
+}
+
+// Alphabet builds the Alphabet described by the Definition, returning a
+// *DefinitionError if any Letter is malformed.
+func (d Definition) Alphabet() (Alphabet, error) {
+	named := map[string]Class{}
+	for shorthand, name := range d.Shorthands {
+		fail := func(format string, a ...any) error {
+			return &DefinitionError{Line: d.ShorthandLines[shorthand], Message: fmt.Sprintf(format, a...)}
+		}
+		if utf8.RuneCountInString(shorthand) != 1 {
+			return nil, fail("shorthand %q must be a single character", shorthand)
+		}
+		if name == "" {
+			return nil, fail("shorthand %q has an empty class name", shorthand)
+		}
+		if _, ok := named[name]; ok {
+			return nil, fail("class %q has more than one shorthand", name)
+		}
+		char, _ := utf8.DecodeRuneInString(shorthand)
+		named[name] = Class(char)
+	}
+	classOf := d.classNamer(named)
+
+	declared := make([]Class, len(d.Classes))
+	for i, class := range d.Classes {
+		if class == "" {
+			return nil, &DefinitionError{Line: d.ClassesLine, Message: "empty class name"}
+		}
+		declared[i] = classOf(class)
+	}
+
+	letters := make([]Letter, len(d.Letters))
+	for i, letterDefinition := range d.Letters {
+		fail := func(format string, a ...any) error {
+			return &DefinitionError{
+				Line:    letterDefinition.Line,
+				Message: fmt.Sprintf("letter %d: ", i+1) + fmt.Sprintf(format, a...),
+			}
+		}
+
+		if letterDefinition.Lower == "" {
+			return nil, fail("missing lower form")
+		}
+		upper := letterDefinition.Upper
+		if upper == "" {
+			upper = letterDefinition.Lower
+		}
+
+		classes := make([]Class, len(letterDefinition.Classes))
+		for j, class := range letterDefinition.Classes {
+			if class == "" {
+				return nil, fail("empty class name")
+			}
+			classes[j] = classOf(class)
+		}
+
+		letters[i] = NewLetter(upper, letterDefinition.Lower, classes...)
+		if letterDefinition.Features != "" {
+			features, err := ParseFeatures(letterDefinition.Features)
+			if err != nil {
+				return nil, fail("%s", strings.TrimPrefix(err.Error(), "alphabet: "))
+			}
+			letters[i] = WithFeatures(letters[i], features)
+		}
+		if letterDefinition.IPA != "" {
+			letters[i] = WithIPA(letters[i], letterDefinition.IPA)
+		}
+		if letterDefinition.XSAMPA != "" {
+			letters[i] = WithXSAMPA(letters[i], letterDefinition.XSAMPA)
+		}
+		if letterDefinition.Native != "" {
+			native, err := ParseCodepoints(letterDefinition.Native)
+			if err != nil {
+				return nil, fail("%s", strings.TrimPrefix(err.Error(), "alphabet: "))
+			}
+			letters[i] = WithNative(letters[i], native)
+		}
+		if letterDefinition.Glyph != "" {
+			letters[i] = WithGlyph(letters[i], letterDefinition.Glyph)
+		}
+		if len(letterDefinition.Positions) > 0 {
+			positions, err := ParsePositions(letterDefinition.Positions...)
+			if err != nil {
+				return nil, fail("%s", strings.TrimPrefix(err.Error(), "alphabet: "))
+			}
+			letters[i] = WithPositions(letters[i], positions)
+		}
+		for class, weight := range letterDefinition.Weights {
+			if class == "" {
+				return nil, fail("weight for an empty class name")
+			}
+			if weight < 0 {
+				return nil, fail("negative weight %v for class %q", weight, class)
+			}
+			letters[i] = WithWeight(letters[i], classOf(class), weight)
+		}
+	}
+
+	options := []Option{WithMetadata(d.Metadata), WithClasses(declared...)}
+	for name, class := range named {
+		options = append(options, WithShorthand(rune(class), ClassName(name)))
+	}
+	return New(letters, options...), nil
+}
+
+// classNamer returns a function that finds the Class of a name in the
+// Definition. Single character names are their own Class. Longer names
+// without a shorthand in named are given private use runes, from U+F0000
+// upward, that the Definition does not already use, and added to named.
+func (d Definition) classNamer(named map[string]Class) func(string) Class {
+	used := map[rune]bool{}
+	use := func(name string) {
+		if utf8.RuneCountInString(name) == 1 {
+			char, _ := utf8.DecodeRuneInString(name)
+			used[char] = true
+		}
+	}
+	for shorthand := range d.Shorthands {
+		use(shorthand)
+	}
+	for _, name := range d.Classes {
+		use(name)
+	}
+	for _, letter := range d.Letters {
+		for _, name := range letter.Classes {
+			use(name)
+		}
+		for name := range letter.Weights {
+			use(name)
+		}
+	}
+
+	next := rune(0xF0000)
+	return func(name string) Class {
+		if class, ok := named[name]; ok {
+			return class
+		}
+		if utf8.RuneCountInString(name) == 1 {
+			char, _ := utf8.DecodeRuneInString(name)
+			return Class(char)
+		}
+		for used[next] {
+			next++
+		}
+		used[next] = true
+		named[name] = Class(next)
+		return Class(next)
+	}
+}
@@ -0,0 +1,110 @@
+package alphabet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDefinitionRoundTrip(t *testing.T) {
+	a := New([]Letter{
+		WithGlyph(NewLetter("A", "a", 'V'), "M 10 90 L 50 10 L 90 90 M 30 50 H 70"),
+		WithWeight(WithIPA(NewLetter("Ŋ", "ŋ", 'C', 'N'), "ŋ"), 'N', 2.5),
+		WithXSAMPA(WithPositions(WithWeight(NewLetter("'", "'", 'C'), 'C', 0.125), Initial|Onset), "?"),
+		WithNative(NewLetter("Ch", "ch", 'C'), "\uE010\uE011"),
+		WithFeatures(NewLetter("Ts", "ts", 'C'), Features{Voice: false, DelayedRelease: true}),
+	},
+		WithMetadata(map[string]string{"name": "Test \"quoted\"", "language code": "tst"}),
+		WithShorthand('N', "nasal"),
+	)
+
+	for _, format := range []struct {
+		Name  string
+		Write func(io.Writer, Alphabet) error
+		Read  func(io.Reader) (Alphabet, error)
+	}{
+		{"JSON", WriteJSON, ReadJSON},
+		{"TOML", WriteTOML, ReadTOML},
+	} {
+		var buffer bytes.Buffer
+		if err := format.Write(&buffer, a); err != nil {
+			t.Log(format.Name, err)
+			t.Fail()
+			continue
+		}
+		t.Logf("%s:\n%s", format.Name, buffer.String())
+		if !strings.Contains(buffer.String(), "\"nasal\"") {
+			t.Logf("Expected %s to write named classes by name\n", format.Name)
+			t.Fail()
+		}
+
+		read, err := format.Read(strings.NewReader(buffer.String()))
+		if err != nil {
+			t.Log(format.Name, err)
+			t.Fail()
+			continue
+		}
+		if !reflect.DeepEqual(Define(a), Define(read)) {
+			t.Logf("Expected %s round trip of %v to equal %v\n", format.Name, Define(a), Define(read))
+			t.Fail()
+		}
+	}
+}
+
+func TestDefinitionClassNames(t *testing.T) {
+	input := "{\"shorthands\": {\"N\": \"nasal\"}, \"letters\": [\n" +
+		"{\"lower\": \"m\", \"classes\": [\"C\", \"nasal\", \"labial\"]},\n" +
+		"{\"lower\": \"\U000F0000\", \"classes\": [\"\U000F0000\"]}]}"
+	a, err := ReadJSON(strings.NewReader(input))
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	m := a.GetLetters().ToSlice()[0]
+	nasal, _ := ClassNamed(a, "nasal")
+	labial, ok := ClassNamed(a, "labial")
+	if nasal != 'N' || !ok || !a.HasClass(m, labial) || labial == '\U000F0000' || a.GetLettersByClass(labial).Len() != 1 {
+		t.Logf("Expected \"labial\" to be given an unused class; got %q\n", labial)
+		t.Fail()
+	}
+}
+
+func TestDefinitionErrors(t *testing.T) {
+	for _, testCase := range []struct {
+		Name  string
+		Read  func(io.Reader) (Alphabet, error)
+		Input string
+		Line  int
+	}{
+		{"JSON missing lower", ReadJSON, "{\n  \"letters\": [\n    {\"lower\": \"a\"},\n    {\"upper\": \"B\"}\n  ]\n}", 4},
+		{"JSON syntax", ReadJSON, "{\n  \"letters\": [\n    {\"lower\": \"a\"}\n    {\"lower\": \"b\"}\n  ]\n}", 4},
+		{"JSON bad class", ReadJSON, "{\"letters\": [\n{\"lower\": \"a\", \"classes\": [\"\"]}]}", 2},
+		{"JSON empty class", ReadJSON, "{\"metadata\": {},\n\"classes\": [\"C\", \"\"],\n\"letters\": []}", 2},
+		{"JSON long shorthand", ReadJSON, "{\"shorthands\": {\n\"N\": \"nasal\",\n\"NN\": \"long nasal\"}, \"letters\": []}", 3},
+		{"JSON empty shorthand", ReadJSON, "{\"shorthands\": {\n\"N\": \"\"}, \"letters\": []}", 2},
+		{"TOML empty class", ReadTOML, "# classes\n\nclasses = [\"C\", \"\"]\n", 3},
+		{"TOML long shorthand", ReadTOML, "[shorthands]\nN = \"nasal\"\nNN = \"long nasal\"\n", 3},
+		{"TOML empty shorthand", ReadTOML, "[shorthands]\n\nN = \"\"\n", 3},
+		{"TOML missing lower", ReadTOML, "[[letters]]\nlower = \"a\"\n\n[[letters]]\nupper = \"B\"\n", 4},
+		{"TOML unknown key", ReadTOML, "[[letters]]\nlower = \"a\"\nshape = \"round\"\n", 3},
+		{"TOML unterminated", ReadTOML, "[metadata]\nname = \"oops\n", 2},
+		{"TOML duplicate letter key", ReadTOML, "[[letters]]\nlower = \"a\"\nupper = \"A\"\nlower = \"b\"\n", 4},
+		{"TOML duplicate classes", ReadTOML, "classes = [\"C\"]\nclasses = [\"V\"]\n", 2},
+		{"TOML hex escape", ReadTOML, "[[letters]]\nlower = \"\\x41\"\n", 2},
+		{"TOML bell escape", ReadTOML, "[[letters]]\nlower = \"\\a\"\n", 2},
+		{"TOML surrogate escape", ReadTOML, "[[letters]]\nlower = \"\\uD800\"\n", 2},
+	} {
+		_, err := testCase.Read(strings.NewReader(testCase.Input))
+		var definitionError *DefinitionError
+		if !errors.As(err, &definitionError) {
+			t.Logf("%s: expected a DefinitionError; got %v\n", testCase.Name, err)
+			t.Fail()
+		} else if definitionError.Line != testCase.Line {
+			t.Logf("%s: expected an error on line %d; got %v\n", testCase.Name, testCase.Line, err)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package alphabet
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/jack-reeser/conlang/common"
+)
+
+// Feature is a binary distinctive feature of a sound, such as voice or round.
+type Feature string
+
+// Features used by the default IPA feature table.
+const (
+	Syllabic           Feature = "syllabic"
+	Consonantal        Feature = "consonantal"
+	Sonorant           Feature = "sonorant"
+	Continuant         Feature = "continuant"
+	DelayedRelease     Feature = "delayed-release"
+	Nasal              Feature = "nasal"
+	Lateral            Feature = "lateral"
+	Strident           Feature = "strident"
+	Voice              Feature = "voice"
+	SpreadGlottis      Feature = "spread-glottis"
+	ConstrictedGlottis Feature = "constricted-glottis"
+	Labial             Feature = "labial"
+	Round              Feature = "round"
+	Coronal            Feature = "coronal"
+	Anterior           Feature = "anterior"
+	Distributed        Feature = "distributed"
+	Dorsal             Feature = "dorsal"
+	High               Feature = "high"
+	Low                Feature = "low"
+	Front              Feature = "front"
+	Back               Feature = "back"
+	Tense              Feature = "tense"
+	Long               Feature = "long"
+)
+
+// featureAbbreviations are accepted by ParseFeatures in place of full names.
+var featureAbbreviations = map[string]Feature{
+	"syl":    Syllabic,
+	"cons":   Consonantal,
+	"son":    Sonorant,
+	"cont":   Continuant,
+	"dr":     DelayedRelease,
+	"del":    DelayedRelease,
+	"nas":    Nasal,
+	"lat":    Lateral,
+	"strid":  Strident,
+	"voi":    Voice,
+	"sg":     SpreadGlottis,
+	"cg":     ConstrictedGlottis,
+	"lab":    Labial,
+	"rd":     Round,
+	"cor":    Coronal,
+	"ant":    Anterior,
+	"dist":   Distributed,
+	"dor":    Dorsal,
+	"hi":     High,
+	"lo":     Low,
+	"fr":     Front,
+	"bk":     Back,
+	"tns":    Tense,
+	"voiced": Voice,
+}
+
+// Features is a bundle of feature values, true for + and false for -. A
+// Feature missing from the bundle is unspecified.
+type Features map[Feature]bool
+
+// ParseFeatures parses a feature bundle written as "[+voice -continuant]". The
+// brackets are optional, values may be separated by spaces or commas, and
+// common abbreviations like "cont" and "son" are accepted.
+func ParseFeatures(s string) (Features, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	features := Features{}
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		value := field[0] == '+'
+		if !value && field[0] != '-' {
+			return nil, fmt.Errorf("alphabet: feature value %q must start with '+' or '-'", field)
+		}
+		name := Feature(strings.ToLower(field[1:]))
+		if full, ok := featureAbbreviations[string(name)]; ok {
+			name = full
+		}
+		if name == "" {
+			return nil, fmt.Errorf("alphabet: feature value %q has no name", field)
+		}
+		features[name] = value
+	}
+	return features, nil
+}
+
+// String writes the bundle in the form ParseFeatures reads, sorted by name.
+func (f Features) String() string {
+	names := make([]string, 0, len(f))
+	for feature := range f {
+		names = append(names, string(feature))
+	}
+	slices.Sort(names)
+	for i, name := range names {
+		if f[Feature(name)] {
+			names[i] = "+" + name
+		} else {
+			names[i] = "-" + name
+		}
+	}
+	return "[" + strings.Join(names, " ") + "]"
+}
+
+// Matches returns true if every value in the bundle is also in other. An empty
+// bundle matches anything.
+func (f Features) Matches(other Features) bool {
+	for feature, value := range f {
+		if otherValue, ok := other[feature]; !ok || otherValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// With returns a copy of the bundle with the values in changes added or
+// replaced.
+func (f Features) With(changes Features) Features {
+	features := make(Features, len(f)+len(changes))
+	maps.Copy(features, f)
+	maps.Copy(features, changes)
+	return features
+}
+
+// Equal returns true if both bundles hold exactly the same values.
+func (f Features) Equal(other Features) bool { return maps.Equal(f, other) }
+
+// distance counts the Features whose values differ between two bundles,
+// including Features specified in only one of them.
+func (f Features) distance(other Features) (n int) {
+	for feature, value := range f {
+		if otherValue, ok := other[feature]; !ok || otherValue != value {
+			n++
+		}
+	}
+	for feature := range other {
+		if _, ok := f[feature]; !ok {
+			n++
+		}
+	}
+	return
+}
+
+func (b basicAlphabet) GetLettersByFeatures(features Features) common.Collection[Letter] {
+	return b.Select(func(l Letter) bool { return features.Matches(l.Features()) })
+}
+
+func (b basicAlphabet) Modify(l Letter, changes Features) (Letter, bool) {
+	target := l.Features().With(changes)
+	var best Letter
+	bestDistance := 0
+	for _, candidate := range b.ToSlice() {
+		if !changes.Matches(candidate.Features()) {
+			continue
+		}
+		if distance := target.distance(candidate.Features()); best == nil || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best, best != nil
+}
@@ -0,0 +1,92 @@
+package alphabet
+
+import "testing"
+
+func ipaLetter(t *testing.T, upper, lower, ipa string) Letter {
+	features, ok := IPAFeatures(ipa)
+	if !ok {
+		t.Fatalf("Expected %q to be in the IPA feature table\n", ipa)
+	}
+	return WithFeatures(NewLetter(upper, lower), features)
+}
+
+func TestFeatures(t *testing.T) {
+	a := New([]Letter{
+		ipaLetter(t, "P", "p", "p"),
+		ipaLetter(t, "B", "b", "b"),
+		ipaLetter(t, "T", "t", "t"),
+		ipaLetter(t, "D", "d", "d"),
+		ipaLetter(t, "K", "k", "k"),
+		ipaLetter(t, "S", "s", "s"),
+		ipaLetter(t, "Z", "z", "z"),
+		ipaLetter(t, "SH", "sh", "ʃ"),
+		ipaLetter(t, "M", "m", "m"),
+		ipaLetter(t, "A", "a", "a"),
+		ipaLetter(t, "I", "i", "i"),
+		ipaLetter(t, "U", "u", "u"),
+		ipaLetter(t, "Á", "á", "aː"),
+	})
+
+	for _, testCase := range []struct {
+		Expr   string
+		Output string
+	}{
+		{"[+voice -continuant -sonorant]", "b.d"},
+		{"[-syl +cont]", "s.z.sh"},
+		{"[+syllabic] & ![+long]", "a.i.u"},
+		{"[+coronal +strident] | [+nasal]", "s.z.sh.m"},
+	} {
+		letters, err := a.Query(testCase.Expr)
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		if output := lettersToString(letters.ToSlice()); output != testCase.Output {
+			t.Logf("Expected %q to select %s; got %s\n", testCase.Expr, testCase.Output, output)
+			t.Fail()
+		}
+	}
+
+	voiced := Features{Voice: true}
+	for _, testCase := range []struct {
+		Input   string
+		Changes Features
+		Output  string
+	}{
+		{"p", voiced, "b"},
+		{"t", voiced, "d"},
+		{"s", voiced, "z"},
+		{"z", Features{Voice: false}, "s"},
+		{"a", Features{Long: true}, "á"},
+		{"k", voiced, ""},
+	} {
+		letters, _ := a.Tokenize(testCase.Input)
+		modified, ok := a.Modify(letters[0], testCase.Changes)
+		if !ok {
+			t.Logf("Expected a letter with %v\n", testCase.Changes)
+			t.Fail()
+			continue
+		}
+		if exact := modified.Features().Equal(letters[0].Features().With(testCase.Changes)); exact != (testCase.Output != "") {
+			t.Logf("Expected %q modified by %v to be exact: %t\n", testCase.Input, testCase.Changes, testCase.Output != "")
+			t.Fail()
+		} else if exact && modified.Lower() != testCase.Output {
+			t.Logf("Expected %q modified by %v to be %q; got %q\n", testCase.Input, testCase.Changes, testCase.Output, modified)
+			t.Fail()
+		}
+	}
+
+	if features, err := ParseFeatures("[+voi, -cont]"); err != nil || features.String() != "[-continuant +voice]" {
+		t.Logf("Expected abbreviations to parse; got %v (%v)\n", features, err)
+		t.Fail()
+	}
+	if _, err := ParseFeatures("[voice]"); err == nil {
+		t.Log("Expected a feature without a value to fail to parse")
+		t.Fail()
+	}
+	if symbol, exact := IPASymbol(a.GetLettersByFeatures(Features{Strident: true, Anterior: false}).ToSlice()[0].Features()); symbol != "ʃ" || !exact {
+		t.Logf("Expected the IPA symbol for sh to be ʃ; got %q\n", symbol)
+		t.Fail()
+	}
+}
@@ -0,0 +1,294 @@
+package alphabet
+
+import (
+	"slices"
+	"strings"
+)
+
+// place and manner describe how a consonant in the default IPA feature table
+// is articulated.
+type (
+	place  int
+	manner int
+)
+
+const (
+	bilabial place = iota
+	labiodental
+	dental
+	alveolar
+	postalveolar
+	retroflex
+	palatal
+	velar
+	uvular
+	pharyngeal
+	glottal
+	labiovelar
+)
+
+const (
+	stop manner = iota
+	affricate
+	fricative
+	lateralFricative
+	nasal
+	trill
+	tap
+	approximant
+	lateralApproximant
+)
+
+// consonant returns the Features of a pulmonic consonant.
+func consonant(p place, m manner, voiced bool) Features {
+	f := Features{
+		Syllabic:       false,
+		Consonantal:    true,
+		Sonorant:       m >= nasal,
+		Continuant:     m != stop && m != affricate && m != nasal,
+		DelayedRelease: m == affricate,
+		Nasal:          m == nasal,
+		Lateral:        m == lateralFricative || m == lateralApproximant,
+		Voice:          voiced || m >= nasal,
+		Strident:       false,
+		Labial:         p == bilabial || p == labiodental || p == labiovelar,
+		Coronal:        p >= dental && p <= retroflex,
+		Dorsal:         p >= palatal && p <= uvular || p == labiovelar,
+	}
+
+	switch p {
+	case dental, alveolar, postalveolar, retroflex:
+		f[Anterior] = p <= alveolar
+		f[Distributed] = p == dental || p == postalveolar
+		f[Strident] = (m == fricative || m == affricate) && p != dental
+	case labiodental:
+		f[Strident] = m == fricative || m == affricate
+	case palatal, velar, uvular, labiovelar:
+		f[High] = p != uvular
+		f[Low] = false
+		f[Front] = p == palatal
+		f[Back] = p != palatal
+	case pharyngeal:
+		f[Consonantal] = false
+	case glottal:
+		f[Consonantal] = false
+		f[Sonorant] = false
+		f[SpreadGlottis] = m == fricative
+		f[ConstrictedGlottis] = m == stop
+	}
+	if p == labiovelar {
+		f[Round] = true
+	}
+	if m == approximant {
+		// central approximants are glides
+		f[Consonantal] = p == alveolar || p == retroflex || p == labiodental
+	}
+	return f
+}
+
+// height and backness place a vowel in the default IPA feature table.
+type (
+	height   int
+	backness int
+)
+
+const (
+	close height = iota
+	nearClose
+	closeMid
+	mid
+	openMid
+	open
+)
+
+const (
+	front backness = iota
+	central
+	back
+)
+
+// vowel returns the Features of a plain vowel.
+func vowel(h height, b backness, round bool) Features {
+	return Features{
+		Syllabic:    true,
+		Consonantal: false,
+		Sonorant:    true,
+		Continuant:  true,
+		Voice:       true,
+		Nasal:       false,
+		Labial:      round,
+		Round:       round,
+		Dorsal:      true,
+		High:        h <= nearClose,
+		Low:         h == open,
+		Front:       b == front,
+		Back:        b == back,
+		Tense:       h == close || h == closeMid,
+	}
+}
+
+// ipaFeatures is the default IPA feature table.
+var ipaFeatures = map[string]Features{
+	// plosives
+	"p": consonant(bilabial, stop, false),
+	"b": consonant(bilabial, stop, true),
+	"t": consonant(alveolar, stop, false),
+	"d": consonant(alveolar, stop, true),
+	"ʈ": consonant(retroflex, stop, false),
+	"ɖ": consonant(retroflex, stop, true),
+	"c": consonant(palatal, stop, false),
+	"ɟ": consonant(palatal, stop, true),
+	"k": consonant(velar, stop, false),
+	"ɡ": consonant(velar, stop, true),
+	"g": consonant(velar, stop, true),
+	"q": consonant(uvular, stop, false),
+	"ɢ": consonant(uvular, stop, true),
+	"ʔ": consonant(glottal, stop, false),
+	// affricates
+	"ts":  consonant(alveolar, affricate, false),
+	"dz":  consonant(alveolar, affricate, true),
+	"tʃ":  consonant(postalveolar, affricate, false),
+	"dʒ":  consonant(postalveolar, affricate, true),
+	"t͡s": consonant(alveolar, affricate, false),
+	"d͡z": consonant(alveolar, affricate, true),
+	"t͡ʃ": consonant(postalveolar, affricate, false),
+	"d͡ʒ": consonant(postalveolar, affricate, true),
+	"pf":  consonant(labiodental, affricate, false),
+	// nasals
+	"m": consonant(bilabial, nasal, true),
+	"ɱ": consonant(labiodental, nasal, true),
+	"n": consonant(alveolar, nasal, true),
+	"ɳ": consonant(retroflex, nasal, true),
+	"ɲ": consonant(palatal, nasal, true),
+	"ŋ": consonant(velar, nasal, true),
+	"ɴ": consonant(uvular, nasal, true),
+	// trills and taps
+	"ʙ": consonant(bilabial, trill, true),
+	"r": consonant(alveolar, trill, true),
+	"ʀ": consonant(uvular, trill, true),
+	"ɾ": consonant(alveolar, tap, true),
+	"ɽ": consonant(retroflex, tap, true),
+	// fricatives
+	"ɸ": consonant(bilabial, fricative, false),
+	"β": consonant(bilabial, fricative, true),
+	"f": consonant(labiodental, fricative, false),
+	"v": consonant(labiodental, fricative, true),
+	"θ": consonant(dental, fricative, false),
+	"ð": consonant(dental, fricative, true),
+	"s": consonant(alveolar, fricative, false),
+	"z": consonant(alveolar, fricative, true),
+	"ʃ": consonant(postalveolar, fricative, false),
+	"ʒ": consonant(postalveolar, fricative, true),
+	"ʂ": consonant(retroflex, fricative, false),
+	"ʐ": consonant(retroflex, fricative, true),
+	"ç": consonant(palatal, fricative, false),
+	"ʝ": consonant(palatal, fricative, true),
+	"x": consonant(velar, fricative, false),
+	"ɣ": consonant(velar, fricative, true),
+	"χ": consonant(uvular, fricative, false),
+	"ʁ": consonant(uvular, fricative, true),
+	"ħ": consonant(pharyngeal, fricative, false),
+	"ʕ": consonant(pharyngeal, fricative, true),
+	"h": consonant(glottal, fricative, false),
+	"ɦ": consonant(glottal, fricative, true),
+	"ɬ": consonant(alveolar, lateralFricative, false),
+	"ɮ": consonant(alveolar, lateralFricative, true),
+	// approximants
+	"ʋ": consonant(labiodental, approximant, true),
+	"ɹ": consonant(alveolar, approximant, true),
+	"ɻ": consonant(retroflex, approximant, true),
+	"j": consonant(palatal, approximant, true),
+	"ɰ": consonant(velar, approximant, true),
+	"w": consonant(labiovelar, approximant, true),
+	"l": consonant(alveolar, lateralApproximant, true),
+	"ɭ": consonant(retroflex, lateralApproximant, true),
+	"ʎ": consonant(palatal, lateralApproximant, true),
+	"ʟ": consonant(velar, lateralApproximant, true),
+	// vowels
+	"i": vowel(close, front, false),
+	"y": vowel(close, front, true),
+	"ɨ": vowel(close, central, false),
+	"ʉ": vowel(close, central, true),
+	"ɯ": vowel(close, back, false),
+	"u": vowel(close, back, true),
+	"ɪ": vowel(nearClose, front, false),
+	"ʏ": vowel(nearClose, front, true),
+	"ʊ": vowel(nearClose, back, true),
+	"e": vowel(closeMid, front, false),
+	"ø": vowel(closeMid, front, true),
+	"ɘ": vowel(closeMid, central, false),
+	"ɵ": vowel(closeMid, central, true),
+	"ɤ": vowel(closeMid, back, false),
+	"o": vowel(closeMid, back, true),
+	"ə": vowel(mid, central, false),
+	"ɛ": vowel(openMid, front, false),
+	"œ": vowel(openMid, front, true),
+	"ɜ": vowel(openMid, central, false),
+	"ɞ": vowel(openMid, central, true),
+	"ʌ": vowel(openMid, back, false),
+	"ɔ": vowel(openMid, back, true),
+	"æ": vowel(open, front, false),
+	"a": vowel(open, central, false),
+	"ɶ": vowel(open, front, true),
+	"ɐ": vowel(open, central, false),
+	"ɑ": vowel(open, back, false),
+	"ɒ": vowel(open, back, true),
+}
+
+// ipaModifiers are diacritics that IPAFeatures applies to the symbol before
+// them.
+var ipaModifiers = []struct {
+	suffix  string
+	changes Features
+}{
+	{"ː", Features{Long: true}},
+	{"ʰ", Features{SpreadGlottis: true}},
+	{"ʷ", Features{Labial: true, Round: true}},
+	{"̃", Features{Nasal: true}},
+	{"̥", Features{Voice: false}},
+	{"̊", Features{Voice: false}},
+	{"̬", Features{Voice: true}},
+	{"̩", Features{Syllabic: true}},
+	{"̍", Features{Syllabic: true}},
+}
+
+// IPAFeatures returns the default Features of an IPA symbol, such as "ʃ", "tʃ"
+// or "aː". Length, aspiration, labialization, nasalization, voicing and
+// syllabicity diacritics are applied to the base symbol. It returns false if
+// the symbol is not in the table.
+func IPAFeatures(symbol string) (Features, bool) {
+	if features, ok := ipaFeatures[symbol]; ok {
+		return features.With(nil), true
+	}
+	for _, modifier := range ipaModifiers {
+		if base, ok := strings.CutSuffix(symbol, modifier.suffix); ok && base != "" {
+			if features, ok := IPAFeatures(base); ok {
+				return features.With(modifier.changes), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// IPASymbols returns every symbol in the default IPA feature table, sorted.
+func IPASymbols() []string {
+	symbols := make([]string, 0, len(ipaFeatures))
+	for symbol := range ipaFeatures {
+		symbols = append(symbols, symbol)
+	}
+	slices.Sort(symbols)
+	return symbols
+}
+
+// IPASymbol returns the symbol from the default IPA feature table whose
+// Features match the given bundle most closely, preferring an exact match.
+func IPASymbol(features Features) (symbol string, exact bool) {
+	best := -1
+	for candidate, candidateFeatures := range ipaFeatures {
+		distance := features.distance(candidateFeatures)
+		if best < 0 || distance < best || distance == best && candidate < symbol {
+			symbol, best = candidate, distance
+		}
+	}
+	return symbol, best == 0
+}
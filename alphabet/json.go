@@ -0,0 +1,137 @@
+package alphabet
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// WriteJSON writes the Definition of an Alphabet to w as indented JSON.
+func WriteJSON(w io.Writer, a Alphabet) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(Define(a))
+}
+
+// ReadJSON reads an Alphabet written by WriteJSON. Malformed input is reported
+// as a *DefinitionError carrying the line of the problem.
+func ReadJSON(r io.Reader) (Alphabet, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	definition, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return definition.Alphabet()
+}
+
+// decodeJSON walks the top level of the document by token so that each Letter,
+// the classes and each shorthand can be tagged with the line they start on.
+func decodeJSON(data []byte) (definition Definition, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	fail := func(offset int64, format string, a ...any) error {
+		return &DefinitionError{Line: lineAt(data, offset), Message: fmt.Sprintf(format, a...)}
+	}
+	wrap := func(err error) error {
+		var syntaxError *json.SyntaxError
+		var typeError *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxError):
+			return fail(syntaxError.Offset-1, "%s", syntaxError)
+		case errors.As(err, &typeError):
+			return fail(typeError.Offset-1, "%s", typeError)
+		case errors.Is(err, io.EOF):
+			return fail(int64(len(data)), "unexpected end of input")
+		}
+		return fail(decoder.InputOffset(), "%s", err)
+	}
+	expect := func(want json.Delim) error {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err != nil {
+			return wrap(err)
+		}
+		if token != want {
+			return fail(offset, "expected %q, got %v", want, token)
+		}
+		return nil
+	}
+
+	if err = expect('{'); err != nil {
+		return
+	}
+	for decoder.More() {
+		offset := decoder.InputOffset()
+		var token json.Token
+		if token, err = decoder.Token(); err != nil {
+			return definition, wrap(err)
+		}
+		switch token {
+		case "metadata":
+			if err = decoder.Decode(&definition.Metadata); err != nil {
+				return definition, wrap(err)
+			}
+		case "classes":
+			definition.ClassesLine = lineAt(data, decoder.InputOffset())
+			if err = decoder.Decode(&definition.Classes); err != nil {
+				return definition, wrap(err)
+			}
+		case "shorthands":
+			if err = expect('{'); err != nil {
+				return
+			}
+			definition.Shorthands = map[string]string{}
+			definition.ShorthandLines = map[string]int{}
+			for decoder.More() {
+				line := lineAt(data, decoder.InputOffset())
+				if token, err = decoder.Token(); err != nil {
+					return definition, wrap(err)
+				}
+				shorthand, _ := token.(string)
+				var name string
+				if err = decoder.Decode(&name); err != nil {
+					return definition, wrap(err)
+				}
+				definition.Shorthands[shorthand] = name
+				definition.ShorthandLines[shorthand] = line
+			}
+			if err = expect('}'); err != nil {
+				return
+			}
+		case "letters":
+			if err = expect('['); err != nil {
+				return
+			}
+			for decoder.More() {
+				letterDefinition := LetterDefinition{Line: lineAt(data, decoder.InputOffset())}
+				if err = decoder.Decode(&letterDefinition); err != nil {
+					return definition, wrap(err)
+				}
+				definition.Letters = append(definition.Letters, letterDefinition)
+			}
+			if err = expect(']'); err != nil {
+				return
+			}
+		default:
+			return definition, fail(offset, "unknown field %v", token)
+		}
+	}
+	err = expect('}')
+	return
+}
+
+// lineAt returns the line of the first non-space, non-separator byte at or
+// after offset.
+func lineAt(data []byte, offset int64) int {
+	offset = max(min(offset, int64(len(data))), 0)
+	for offset < int64(len(data)) && bytes.IndexByte([]byte(" \t\r\n,:"), data[offset]) >= 0 {
+		offset++
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
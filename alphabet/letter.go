@@ -2,11 +2,18 @@ package alphabet
 
 import (
 	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/jack-reeser/conlang/common"
 )
 
 // Letter is a symbol that represents a sound.
+//
+// Letter is meant to be implemented by NewLetter only. Features, IPA, XSAMPA,
+// Native, Glyph, Weights and Positions were added to it after the first
+// release, so Letters implemented outside this package must add them, and the
+// With functions return such Letters unchanged.
 type Letter interface {
 	// Upper returns a string that represents the uppercase version of the letter.
 	Upper() string
@@ -18,6 +25,30 @@ type Letter interface {
 	GetClassSlice() []Class
 	// GetClassMap returns the letter's underlying Class map.
 	GetClassMap() map[Class]bool
+	// Features returns the distinctive Features of the sound the Letter
+	// represents. Features are empty unless set with WithFeatures.
+	Features() Features
+	// IPA returns the broad IPA transcription of the sound the Letter usually
+	// represents, or an empty string if none was set with WithIPA.
+	IPA() string
+	// XSAMPA returns the X-SAMPA transcription of the sound the Letter
+	// usually represents: the one set with WithXSAMPA, or else its IPA
+	// converted by the XSAMPA function.
+	XSAMPA() string
+	// Native returns the Letter written in its native script, usually as
+	// Private Use Area codepoints, or an empty string if none was set with
+	// WithNative.
+	Native() string
+	// Glyph returns SVG path data for the shape of the Letter in its native
+	// script, or an empty string if none was set with WithGlyph.
+	Glyph() string
+	// Weights returns how often the Letter should be chosen relative to the
+	// other Letters of each Class, as set with WithWeight. Classes without a
+	// weight are absent from the map.
+	Weights() map[Class]float64
+	// Positions returns where in a word or syllable the Letter may occur, as
+	// set with WithPositions.
+	Positions() Position
 	fmt.Stringer
 }
 
@@ -31,31 +62,127 @@ func NewLetter(upper, lower string, classes ...Class) Letter {
 
 	if upper == lower {
 		return simpleLetter{
-			letter:   lower,
-			classSet: letterClassSet,
+			letter:     lower,
+			properties: properties{classSet: letterClassSet},
 		}
 	}
 
 	return fullLetter{
-		upper:    upper,
-		lower:    lower,
-		classSet: letterClassSet,
+		upper:      upper,
+		lower:      lower,
+		properties: properties{classSet: letterClassSet},
 	}
 }
 
+// WithFeatures returns a copy of the Letter with the given Features. Letters
+// that were not made by NewLetter are returned unchanged.
+func WithFeatures(l Letter, features Features) Letter {
+	return withProperties(l, func(p *properties) { p.features = features.With(nil) })
+}
+
 // classSet is a reusable private set of Classes
 type classSet map[Class]bool
 
 func (c classSet) IsClass(class Class) bool    { return c[class] }
 func (c classSet) GetClassMap() map[Class]bool { return c }
 func (c classSet) GetClassSlice() []Class {
-	return common.CollectionFrom[Class](c).ToSlice()
+	return common.CollectionFrom[Class](map[Class]bool(c)).ToSlice()
+}
+
+// WithIPA returns a copy of the Letter with the given IPA transcription. If the
+// Letter has no Features yet, it is given the default Features of the symbol
+// from IPAFeatures, when there are any. Letters that were not made by
+// NewLetter are returned unchanged.
+func WithIPA(l Letter, ipa string) Letter {
+	return withProperties(l, func(p *properties) {
+		p.ipa = ipa
+		if features, ok := IPAFeatures(ipa); ok && len(p.features) == 0 {
+			p.features = features
+		}
+	})
+}
+
+// WithXSAMPA returns a copy of the Letter with the given X-SAMPA
+// transcription, for sounds the XSAMPA function does not spell the way the
+// alphabet needs. Letters that were not made by NewLetter are returned
+// unchanged.
+func WithXSAMPA(l Letter, xsampa string) Letter {
+	return withProperties(l, func(p *properties) { p.xsampa = xsampa })
+}
+
+// WithNative returns a copy of the Letter written as native in its native
+// script. Letters that were not made by NewLetter are returned unchanged.
+func WithNative(l Letter, native string) Letter {
+	return withProperties(l, func(p *properties) { p.native = native })
+}
+
+// WithGlyph returns a copy of the Letter drawn by the given SVG path data. See
+// the glyph package for how paths are laid out. Letters that were not made by
+// NewLetter are returned unchanged.
+func WithGlyph(l Letter, path string) Letter {
+	return withProperties(l, func(p *properties) { p.glyph = path })
+}
+
+// WithWeight returns a copy of the Letter with the given relative frequency
+// within a Class. See Alphabet.Weight for how weights are used. Letters that
+// were not made by NewLetter are returned unchanged.
+func WithWeight(l Letter, class Class, weight float64) Letter {
+	return withProperties(l, func(p *properties) {
+		weights := make(map[Class]float64, len(p.weights)+1)
+		for c, w := range p.weights {
+			weights[c] = w
+		}
+		weights[class] = weight
+		p.weights = weights
+	})
+}
+
+// properties holds everything about a Letter other than how it is written.
+type properties struct {
+	classSet
+	features  Features
+	ipa       string
+	xsampa    string
+	native    string
+	glyph     string
+	weights   map[Class]float64
+	positions Position
+}
+
+func (p properties) Features() Features { return p.features }
+func (p properties) IPA() string        { return p.ipa }
+func (p properties) XSAMPA() string {
+	if p.xsampa != "" {
+		return p.xsampa
+	}
+	return XSAMPA(p.ipa)
+}
+func (p properties) Native() string { return p.native }
+func (p properties) Glyph() string  { return p.glyph }
+func (p properties) Weights() map[Class]float64 {
+	return p.weights
+}
+func (p properties) Positions() Position { return p.positions }
+
+// withProperties returns a copy of l with its properties changed by f. Setters
+// must replace rather than modify the maps in properties, since they are
+// shared between copies.
+func withProperties(l Letter, f func(*properties)) Letter {
+	switch letter := l.(type) {
+	case simpleLetter:
+		f(&letter.properties)
+		return letter
+	case fullLetter:
+		f(&letter.properties)
+		return letter
+	}
+	return l
 }
 
 // simpleLetter represents letters that do not have distinct upper and lower values
 type simpleLetter struct {
 	letter string
-	classSet
+	properties
 }
 
 func (s simpleLetter) Upper() string  { return s.letter }
@@ -66,9 +193,29 @@ func (s simpleLetter) String() string { return s.letter }
 type fullLetter struct {
 	upper string
 	lower string
-	classSet
+	properties
 }
 
 func (s fullLetter) Upper() string  { return s.upper }
 func (s fullLetter) Lower() string  { return s.lower }
 func (s fullLetter) String() string { return s.lower }
+
+// title returns the titlecase form of a Letter: its upper form for single rune
+// letters, or the first rune of the upper form followed by the rest of the
+// lower form for multigraphs like "Sh" or "Ll".
+func title(l Letter) string {
+	upper, lower := []rune(l.Upper()), []rune(l.Lower())
+	if len(upper) <= 1 || len(upper) != len(lower) {
+		return l.Upper()
+	}
+	return string(upper[:1]) + string(lower[1:])
+}
+
+// allCaps returns the fully uppercased form of a Letter. This is its upper form
+// unless that is a titlecase multigraph like "Ll", which becomes "LL".
+func allCaps(l Letter) string {
+	if upper := l.Upper(); utf8.RuneCountInString(upper) > 1 && title(l) == upper {
+		return strings.ToUpper(upper)
+	}
+	return l.Upper()
+}
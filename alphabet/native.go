@@ -0,0 +1,126 @@
+package alphabet
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NativeError is returned by Render when a Letter has no native form.
+type NativeError struct {
+	Input  string
+	Offset int
+	Text   string
+}
+
+func (e *NativeError) Error() string {
+	return fmt.Sprintf("alphabet: no native form for %q at byte offset %d of %q", e.Text, e.Offset, e.Input)
+}
+
+// Render converts romanized text to the native script of the Alphabet using
+// each Letter's Native form. Text that is not part of the Alphabet, such as
+// spaces and punctuation, is copied as is.
+func Render(a Alphabet, s string) (string, error) {
+	var b strings.Builder
+	for _, segment := range a.Segment(s) {
+		switch {
+		case segment.Letter == nil:
+			b.WriteString(segment.Text)
+		case segment.Letter.Native() == "":
+			return "", &NativeError{Input: s, Offset: segment.Offset, Text: segment.Text}
+		default:
+			b.WriteString(segment.Letter.Native())
+		}
+	}
+	return b.String(), nil
+}
+
+// Romanize converts text in the native script of the Alphabet back to the
+// lower forms of its Letters, matching the longest native form first. Text
+// without a native form is copied as is.
+func Romanize(a Alphabet, s string) string {
+	letters := a.GetLetters().ToSlice()
+	native := map[string]Letter{}
+	longest := 0
+	for _, letter := range letters {
+		if form := letter.Native(); form != "" {
+			if _, ok := native[form]; !ok {
+				native[form] = letter
+			}
+			longest = max(longest, len(form))
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		n := min(longest, len(s)-i)
+		for ; n > 0; n-- {
+			if letter, ok := native[s[i:i+n]]; ok {
+				b.WriteString(letter.Lower())
+				break
+			}
+		}
+		if n == 0 {
+			_, n = utf8.DecodeRuneInString(s[i:])
+			b.WriteString(s[i : i+n])
+		}
+		i += n
+	}
+	return b.String()
+}
+
+// WriteRegistry writes the native forms of the Alphabet as a ConScript-style
+// registry table, one line per codepoint in the form
+//
+//	E000;SCRIPT LETTER A;a
+//
+// where SCRIPT is the uppercased "name" metadata of the Alphabet. Letters whose
+// native form spans several codepoints are listed as a sequence.
+func WriteRegistry(w io.Writer, a Alphabet) error {
+	script := strings.ToUpper(a.Metadata()["name"])
+	if script == "" {
+		script = "CONSCRIPT"
+	}
+
+	out := bufio.NewWriter(w)
+	for _, letter := range a.GetLetters().ToSlice() {
+		if letter.Native() == "" {
+			continue
+		}
+		name := "LETTER " + strings.ToUpper(letter.Lower())
+		if strings.IndexFunc(letter.Lower(), func(r rune) bool { return !unicode.IsLetter(r) }) >= 0 {
+			name = "SIGN " + FormatCodepoints(letter.Lower(), " ")
+		}
+		fmt.Fprintf(out, "%s;%s %s;%s\n", FormatCodepoints(letter.Native(), " "), script, name, letter.Lower())
+	}
+	return out.Flush()
+}
+
+// FormatCodepoints writes the codepoints of s in hexadecimal, as in "E000",
+// joined by sep.
+func FormatCodepoints(s, sep string) string {
+	codepoints := make([]string, 0, len(s))
+	for _, r := range s {
+		codepoints = append(codepoints, fmt.Sprintf("%04X", r))
+	}
+	return strings.Join(codepoints, sep)
+}
+
+// ParseCodepoints reads codepoints written as hexadecimal with an optional
+// "U+" prefix, separated by spaces or commas, such as "U+E000 U+E001".
+func ParseCodepoints(s string) (string, error) {
+	var b strings.Builder
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || unicode.IsSpace(r) }) {
+		hex := strings.TrimPrefix(strings.TrimPrefix(field, "U+"), "u+")
+		codepoint, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || !utf8.ValidRune(rune(codepoint)) {
+			return "", fmt.Errorf("alphabet: invalid codepoint %q", field)
+		}
+		b.WriteRune(rune(codepoint))
+	}
+	return b.String(), nil
+}
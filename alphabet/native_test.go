@@ -0,0 +1,67 @@
+package alphabet
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNative(t *testing.T) {
+	a := New([]Letter{
+		WithNative(NewLetter("A", "a", 'V'), "\uE000"),
+		WithNative(NewLetter("K", "k", 'C'), "\uE001"),
+		WithNative(NewLetter("SH", "sh", 'C'), "\uE002"),
+		WithNative(NewLetter("'", "'", 'C'), "\uE003"),
+		WithNative(NewLetter("NG", "ng", 'C'), "\uE004\uE005"),
+	}, WithMetadata(map[string]string{"name": "Kesh"}))
+
+	rendered, err := Render(a, "Shaka ang'a!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "\uE002\uE000\uE001\uE000 \uE000\uE004\uE005\uE003\uE000!"; rendered != expected {
+		t.Logf("Expected %q to equal %q\n", rendered, expected)
+		t.Fail()
+	}
+	if romanized := Romanize(a, rendered); romanized != "shaka ang'a!" {
+		t.Logf("Expected %q to romanize as \"shaka ang'a!\"; got %q\n", rendered, romanized)
+		t.Fail()
+	}
+	if _, err := Render(a, "shako"); err != nil {
+		t.Logf("Expected text outside the alphabet to pass through; got %v\n", err)
+		t.Fail()
+	}
+
+	var registry strings.Builder
+	if err := WriteRegistry(&registry, a); err != nil {
+		t.Fatal(err)
+	}
+	expected := "E000;KESH LETTER A;a\nE001;KESH LETTER K;k\nE002;KESH LETTER SH;sh\nE003;KESH SIGN 0027;'\nE004 E005;KESH LETTER NG;ng\n"
+	if registry.String() != expected {
+		t.Logf("Expected registry:\n%s\ngot:\n%s", expected, registry.String())
+		t.Fail()
+	}
+
+	collisions := New([]Letter{
+		WithNative(NewLetter("A", "a", 'V'), "\uE000"),
+		WithNative(NewLetter("E", "e", 'V'), "\uE000"),
+		NewLetter("I", "i", 'V'),
+		WithNative(NewLetter("O", "o", 'V'), "\uE004\uE005"),
+		WithNative(NewLetter("U", "u", 'V'), "\uE004"),
+		WithNative(NewLetter("Y", "y", 'V'), "\uE006\uE006"),
+	}).Validate()
+	if len(collisions) != 2 || collisions[0].Kind != NativeCollision || collisions[1].Kind != NativeCollision || collisions[1].Example != "\uE004" {
+		t.Logf("Expected native collisions for a and e and for o and u; got %v\n", collisions)
+		t.Fail()
+	}
+
+	if native, err := ParseCodepoints("U+E000, e001"); err != nil || native != "\uE000\uE001" {
+		t.Logf("Expected codepoints to parse; got %q (%v)\n", native, err)
+		t.Fail()
+	}
+	for _, input := range []string{"U+D800", "DFFF", "110000", "E00G"} {
+		if _, err := ParseCodepoints(input); err == nil {
+			t.Logf("Expected %q not to parse\n", input)
+			t.Fail()
+		}
+	}
+}
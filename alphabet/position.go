@@ -0,0 +1,202 @@
+package alphabet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Position is a set of places in a word or syllable a Letter may occur in.
+// Word positions (Initial, Medial, Final) and syllable positions (Onset,
+// Nucleus, Coda) are constrained separately: a Letter with no positions of one
+// kind may occur in any position of that kind.
+type Position int
+
+const (
+	// Initial is the first Letter of a word.
+	Initial Position = 1 << iota
+	// Medial is any Letter of a word that is neither first nor last.
+	Medial
+	// Final is the last Letter of a word.
+	Final
+	// Onset is a Letter before the nucleus of its syllable.
+	Onset
+	// Nucleus is the peak of a syllable, usually a vowel.
+	Nucleus
+	// Coda is a Letter after the nucleus of its syllable.
+	Coda
+
+	// Anywhere places no constraint on a Letter.
+	Anywhere Position = 0
+
+	wordPositions     = Initial | Medial | Final
+	syllablePositions = Onset | Nucleus | Coda
+)
+
+var positionNames = []struct {
+	position Position
+	name     string
+}{
+	{Initial, "initial"},
+	{Medial, "medial"},
+	{Final, "final"},
+	{Onset, "onset"},
+	{Nucleus, "nucleus"},
+	{Coda, "coda"},
+}
+
+// ParsePositions returns the Position named by each of the given names, such as
+// "initial" or "coda", combined.
+func ParsePositions(names ...string) (Position, error) {
+	var p Position
+	for _, name := range names {
+		found := false
+		for _, position := range positionNames {
+			if strings.EqualFold(name, position.name) {
+				p |= position.position
+				found = true
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("alphabet: unknown position %q", name)
+		}
+	}
+	return p, nil
+}
+
+// Names returns the names of the positions in p.
+func (p Position) Names() []string {
+	names := []string{}
+	for _, position := range positionNames {
+		if p&position.position != 0 {
+			names = append(names, position.name)
+		}
+	}
+	return names
+}
+
+func (p Position) String() string {
+	if p == Anywhere {
+		return "anywhere"
+	}
+	groups := []string{}
+	for _, group := range []Position{p & wordPositions, p & syllablePositions} {
+		if group != 0 {
+			groups = append(groups, strings.Join(group.Names(), " or "))
+		}
+	}
+	return strings.Join(groups, ", ")
+}
+
+// Allows returns true if a Letter with Positions p may occur at the given
+// positions, which hold at most one word position and one syllable position. A
+// Letter that is both Initial and Final, being the only Letter of its word, is
+// allowed if either is.
+func (p Position) Allows(at Position) bool {
+	if words := p & wordPositions; words != 0 && at&wordPositions != 0 && words&at == 0 {
+		return false
+	}
+	if syllables := p & syllablePositions; syllables != 0 && at&syllablePositions != 0 && syllables&at == 0 {
+		return false
+	}
+	return true
+}
+
+// WithPositions returns a copy of the Letter that may only occur in the given
+// Positions. Letters that were not made by NewLetter are returned unchanged.
+func WithPositions(l Letter, p Position) Letter {
+	return withProperties(l, func(props *properties) { props.positions = p })
+}
+
+// Violation reports a Letter found in a position it may not occur in.
+type Violation struct {
+	Letter Letter
+	// Text and Offset locate the Letter in the word that was checked.
+	Text   string
+	Offset int
+	// Position is where the Letter was found.
+	Position Position
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%q at byte offset %d is %s but may only be %s", v.Text, v.Offset, v.Position, v.Letter.Positions())
+}
+
+// IsNucleus returns true if the Letter can be the nucleus of a syllable,
+// meaning it has the Syllabic feature or belongs to the nucleus Class, which is
+// usually 'V'.
+func IsNucleus(a Alphabet, l Letter, nucleus Class) bool {
+	return l.Features()[Syllabic] || a.HasClass(l, nucleus)
+}
+
+// PositionsOf returns the word and syllable position of each of the Letters of
+// a word. Syllable positions are guessed: Letters for which IsNucleus is true
+// with the nucleus Class are nuclei, a single consonant before each nucleus is
+// its onset, and other consonants are codas, except at the start of the word.
+// Use a syllabifier for anything more precise.
+func PositionsOf(a Alphabet, letters []Letter, nucleus Class) []Position {
+	positions := make([]Position, len(letters))
+	nuclei := make([]bool, len(letters))
+	for i, letter := range letters {
+		nuclei[i] = IsNucleus(a, letter, nucleus)
+	}
+
+	seenNucleus := false
+	for i := range letters {
+		switch {
+		case i == 0 && len(letters) == 1:
+			positions[i] = Initial | Final
+		case i == 0:
+			positions[i] = Initial
+		case i == len(letters)-1:
+			positions[i] = Final
+		default:
+			positions[i] = Medial
+		}
+
+		switch {
+		case nuclei[i]:
+			positions[i] |= Nucleus
+			seenNucleus = true
+		case !seenNucleus, i+1 < len(letters) && nuclei[i+1]:
+			positions[i] |= Onset
+		default:
+			positions[i] |= Coda
+		}
+	}
+	return positions
+}
+
+// CheckPositions tokenizes a word and reports every Letter found in a position
+// it may not occur in. Syllable positions are taken from syllables, which
+// holds one syllable position per Letter, or guessed by PositionsOf with the
+// nucleus Class if it is nil. An error is returned if the word cannot be
+// tokenized.
+func CheckPositions(a Alphabet, word string, nucleus Class, syllables []Position) ([]Violation, error) {
+	letters, err := a.Tokenize(word)
+	var ambiguous *AmbiguousError
+	if err != nil && !errors.As(err, &ambiguous) {
+		return nil, err
+	}
+	if syllables != nil && len(syllables) != len(letters) {
+		return nil, fmt.Errorf("alphabet: %d syllable positions given for %d letters of %q", len(syllables), len(letters), word)
+	}
+
+	positions := PositionsOf(a, letters, nucleus)
+	violations := []Violation{}
+	for i, segment := range a.Segment(word) {
+		at := positions[i]
+		if syllables != nil {
+			at = at&wordPositions | syllables[i]&syllablePositions
+		}
+		if !segment.Letter.Positions().Allows(at) {
+			violations = append(violations, Violation{
+				Letter:   segment.Letter,
+				Text:     segment.Text,
+				Offset:   segment.Offset,
+				Position: at,
+			})
+		}
+	}
+	return violations, nil
+}
@@ -0,0 +1,74 @@
+package alphabet
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPositions(t *testing.T) {
+	a := New([]Letter{
+		NewLetter("A", "a", 'V'),
+		NewLetter("I", "i", 'V'),
+		WithPositions(NewLetter("H", "h", 'C'), Initial|Medial),
+		WithPositions(NewLetter("NG", "ng", 'C'), Coda),
+		WithPositions(NewLetter("'", "'", 'C'), Initial),
+		NewLetter("T", "t", 'C'),
+		NewLetter("K", "k", 'C'),
+	})
+
+	for _, testCase := range []struct {
+		Word       string
+		Violations []string
+	}{
+		{"hat", nil},
+		{"ah", []string{`"h" at byte offset 1 is final, coda but may only be initial or medial`}},
+		{"ngat", []string{`"ng" at byte offset 0 is initial, onset but may only be coda`}},
+		{"tang", nil},
+		{"tangi", []string{`"ng" at byte offset 2 is medial, onset but may only be coda`}},
+		{"tangki", nil},
+		{"'a'i", []string{`"'" at byte offset 2 is medial, onset but may only be initial`}},
+		{"'", nil},
+	} {
+		violations, err := CheckPositions(a, testCase.Word, 'V', nil)
+		if err != nil {
+			t.Log(testCase.Word, err)
+			t.Fail()
+			continue
+		}
+		got := make([]string, len(violations))
+		for i, violation := range violations {
+			got[i] = violation.String()
+		}
+		if strings.Join(got, "; ") != strings.Join(testCase.Violations, "; ") {
+			t.Logf("Expected violations of %q to be %q; got %q\n", testCase.Word, testCase.Violations, got)
+			t.Fail()
+		}
+	}
+
+	// syllable positions given by the caller take precedence over the guess
+	violations, err := CheckPositions(a, "tangi", 'V', []Position{Onset, Nucleus, Coda, Nucleus})
+	if err != nil || len(violations) != 0 {
+		t.Logf("Expected no violations with given syllable positions; got %v (%v)\n", violations, err)
+		t.Fail()
+	}
+	if _, err := CheckPositions(a, "tangi", 'V', []Position{Onset}); err == nil {
+		t.Log("Expected an error for too few syllable positions")
+		t.Fail()
+	}
+
+	for i := 0; i < 50; i++ {
+		if letter := a.GetRandomLetterAt('C', Final|Coda); letter.Lower() == "h" || letter.Lower() == "'" {
+			t.Logf("Expected %q never to be chosen word-finally\n", letter.Lower())
+			t.Fail()
+		}
+	}
+
+	if p, err := ParsePositions("Initial", "coda"); err != nil || p != Initial|Coda {
+		t.Logf("Expected initial and coda; got %v (%v)\n", p, err)
+		t.Fail()
+	}
+	if _, err := ParsePositions("peak"); err == nil {
+		t.Log("Expected an error for an unknown position")
+		t.Fail()
+	}
+}
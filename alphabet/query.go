@@ -0,0 +1,256 @@
+package alphabet
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jack-reeser/conlang/common"
+)
+
+// ClassExpr is a parsed class expression. Class expressions combine Class names
+// with the operators below, listed from tightest to loosest binding:
+//
+//	!A      Letters not in A
+//	A & B   Letters in both A and B
+//	A \ B   Letters in A but not in B
+//	A | B   Letters in either A or B
+//
+// Parentheses group subexpressions. Class names may contain letters, digits,
+// '_' and '-', so "front-vowel" is a single name; "*" matches every Letter, and
+// a feature bundle like "[+voice -continuant]" matches Letters by Features.
+type ClassExpr struct {
+	source string
+	root   classNode
+}
+
+// ParseClassExpr parses a class expression like "C & !N" or "(F | R) \ long".
+func ParseClassExpr(s string) (ClassExpr, error) {
+	p := &classParser{source: s}
+	p.next()
+	root, err := p.parseUnion()
+	if err == nil && p.token.kind != tokenEnd {
+		err = p.errorf("unexpected %q", p.token.text)
+	}
+	if err != nil {
+		return ClassExpr{}, err
+	}
+	return ClassExpr{source: s, root: root}, nil
+}
+
+// Matches returns true if the Letter satisfies the expression in the given
+// Alphabet, which resolves Class names with ClassNamed.
+func (e ClassExpr) Matches(a Alphabet, l Letter) bool {
+	if e.root == nil {
+		return false
+	}
+	return e.root.matches(a, l)
+}
+
+// Classes returns every Class name the expression refers to.
+func (e ClassExpr) Classes() []ClassName {
+	var classes []ClassName
+	if e.root != nil {
+		e.root.collect(&classes)
+	}
+	return classes
+}
+
+func (e ClassExpr) String() string { return e.source }
+
+func (b basicAlphabet) Query(expr string) (common.Collection[Letter], error) {
+	classExpr, err := ParseClassExpr(expr)
+	if err != nil {
+		return nil, err
+	}
+	return b.Select(func(l Letter) bool { return classExpr.Matches(b, l) }), nil
+}
+
+// ClassExprError reports a malformed class expression.
+type ClassExprError struct {
+	Expr    string
+	Offset  int
+	Message string
+}
+
+func (e *ClassExprError) Error() string {
+	return fmt.Sprintf("alphabet: %s at byte offset %d of class expression %q", e.Message, e.Offset, e.Expr)
+}
+
+type classNode interface {
+	matches(Alphabet, Letter) bool
+	collect(*[]ClassName)
+}
+
+type (
+	classLeaf     ClassName
+	classAny      struct{}
+	classFeatures Features
+	classNot      struct{ operand classNode }
+	classBinary   struct {
+		operator    byte
+		left, right classNode
+	}
+)
+
+func (c classLeaf) matches(a Alphabet, l Letter) bool {
+	class, ok := ClassNamed(a, ClassName(c))
+	return ok && a.HasClass(l, class)
+}
+func (c classAny) matches(Alphabet, Letter) bool { return true }
+func (c classFeatures) matches(_ Alphabet, l Letter) bool {
+	return Features(c).Matches(l.Features())
+}
+func (c classNot) matches(a Alphabet, l Letter) bool { return !c.operand.matches(a, l) }
+func (c classBinary) matches(a Alphabet, l Letter) bool {
+	switch c.operator {
+	case '|':
+		return c.left.matches(a, l) || c.right.matches(a, l)
+	case '&':
+		return c.left.matches(a, l) && c.right.matches(a, l)
+	}
+	return c.left.matches(a, l) && !c.right.matches(a, l)
+}
+
+func (c classLeaf) collect(classes *[]ClassName) { *classes = append(*classes, ClassName(c)) }
+func (c classAny) collect(*[]ClassName)          {}
+func (c classFeatures) collect(*[]ClassName)     {}
+func (c classNot) collect(classes *[]ClassName)  { c.operand.collect(classes) }
+func (c classBinary) collect(classes *[]ClassName) {
+	c.left.collect(classes)
+	c.right.collect(classes)
+}
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenName
+	tokenOperator
+	tokenFeatures
+)
+
+type classToken struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+// classParser is a recursive descent parser over a one token lookahead.
+type classParser struct {
+	source string
+	offset int
+	token  classToken
+}
+
+func (p *classParser) errorf(format string, a ...any) error {
+	return &ClassExprError{Expr: p.source, Offset: p.token.offset, Message: fmt.Sprintf(format, a...)}
+}
+
+func isClassNameRune(r rune) bool {
+	return r == '_' || r == '-' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)
+}
+
+func (p *classParser) next() {
+	rest := p.source[p.offset:]
+	trimmed := strings.TrimLeftFunc(rest, unicode.IsSpace)
+	p.offset += len(rest) - len(trimmed)
+	start := p.offset
+
+	switch {
+	case trimmed == "":
+		p.token = classToken{kind: tokenEnd, offset: start}
+	case trimmed[0] == '[':
+		end := strings.IndexByte(trimmed, ']') + 1
+		if end == 0 {
+			end = len(trimmed)
+		}
+		p.offset += end
+		p.token = classToken{kind: tokenFeatures, text: trimmed[:end], offset: start}
+	case strings.ContainsRune("!&|\\()*", rune(trimmed[0])):
+		p.offset++
+		p.token = classToken{kind: tokenOperator, text: trimmed[:1], offset: start}
+	default:
+		end := strings.IndexFunc(trimmed, func(r rune) bool { return !isClassNameRune(r) })
+		if end < 0 {
+			end = len(trimmed)
+		}
+		if end == 0 {
+			// a lone symbol such as '#' or '+' is a single rune class name
+			_, size := utf8.DecodeRuneInString(trimmed)
+			end = size
+		}
+		p.offset += end
+		p.token = classToken{kind: tokenName, text: trimmed[:end], offset: start}
+	}
+}
+
+// parseUnion parses "A | B".
+func (p *classParser) parseUnion() (classNode, error) {
+	return p.parseBinary('|', p.parseDifference)
+}
+
+// parseDifference parses "A \ B".
+func (p *classParser) parseDifference() (classNode, error) {
+	return p.parseBinary('\\', p.parseIntersection)
+}
+
+// parseIntersection parses "A & B".
+func (p *classParser) parseIntersection() (classNode, error) {
+	return p.parseBinary('&', p.parseUnary)
+}
+
+// parseBinary parses a left associative chain of operands joined by operator.
+func (p *classParser) parseBinary(operator byte, operand func() (classNode, error)) (classNode, error) {
+	left, err := operand()
+	for err == nil && p.token.text == string(operator) {
+		p.next()
+		var right classNode
+		if right, err = operand(); err == nil {
+			left = classBinary{operator, left, right}
+		}
+	}
+	return left, err
+}
+
+// parseUnary parses "!A", "(A)", "*", feature bundles and class names.
+func (p *classParser) parseUnary() (classNode, error) {
+	token := p.token
+	switch {
+	case token.kind == tokenName:
+		p.next()
+		return classLeaf(token.text), nil
+	case token.text == "*":
+		p.next()
+		return classAny{}, nil
+	case token.kind == tokenFeatures:
+		if !strings.HasSuffix(token.text, "]") {
+			return nil, p.errorf("expected ']'")
+		}
+		features, err := ParseFeatures(token.text)
+		if err != nil {
+			return nil, p.errorf("%s", strings.TrimPrefix(err.Error(), "alphabet: "))
+		}
+		p.next()
+		return classFeatures(features), nil
+	case token.text == "!":
+		p.next()
+		operand, err := p.parseUnary()
+		return classNot{operand}, err
+	case token.text == "(":
+		p.next()
+		inner, err := p.parseUnion()
+		if err != nil {
+			return nil, err
+		}
+		if p.token.text != ")" {
+			return nil, p.errorf("expected ')'")
+		}
+		p.next()
+		return inner, nil
+	case token.kind == tokenEnd:
+		return nil, p.errorf("unexpected end of expression")
+	}
+	return nil, p.errorf("unexpected %q", token.text)
+}
@@ -0,0 +1,75 @@
+package alphabet
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	a := New([]Letter{
+		NewLetter("A", "a", 'V', 'B'),
+		NewLetter("E", "e", 'V', 'F'),
+		NewLetter("I", "i", 'V', 'F', 'H'),
+		NewLetter("O", "o", 'V', 'B', 'R'),
+		NewLetter("Ü", "ü", 'V', 'F', 'R', 'H'),
+		NewLetter("M", "m", 'C', 'N'),
+		NewLetter("N", "n", 'C', 'N'),
+		NewLetter("P", "p", 'C', 'P'),
+		NewLetter("T", "t", 'C', 'P'),
+		NewLetter("S", "s", 'C', 'S'),
+	}, WithShorthand('B', "back"), WithShorthand('F', "front"), WithShorthand('H', "high"), WithShorthand('R', "round"),
+		WithShorthand('N', "nasal"), WithShorthand('P', "stop"), WithShorthand('S', "fricative"))
+
+	for _, testCase := range []struct {
+		Expr   string
+		Output string
+	}{
+		{"C & !N", "p.t.s"},
+		{"front | round", "e.i.o.ü"},
+		{"V \\ round", "a.e.i"},
+		{"front & round | nasal", "ü.m.n"},
+		{"front & (round | high)", "i.ü"},
+		{"!(V | stop)", "m.n.s"},
+		{"*", "a.e.i.o.ü.m.n.p.t.s"},
+		{"fricative & nasal", ""},
+	} {
+		letters, err := a.Query(testCase.Expr)
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		if output := lettersToString(letters.ToSlice()); output != testCase.Output {
+			t.Logf("Expected %q to select %s; got %s\n", testCase.Expr, testCase.Output, output)
+			t.Fail()
+		}
+	}
+
+	for _, testCase := range []struct {
+		Expr   string
+		Offset int
+	}{
+		{"C & ", 4},
+		{"(C | V", 6},
+		{"C V", 2},
+		{"C && V", 3},
+	} {
+		var exprError *ClassExprError
+		if _, err := a.Query(testCase.Expr); !errors.As(err, &exprError) {
+			t.Logf("Expected %q to fail to parse; got %v\n", testCase.Expr, err)
+			t.Fail()
+		} else if exprError.Offset != testCase.Offset {
+			t.Logf("Expected %q to fail at offset %d; got %v\n", testCase.Expr, testCase.Offset, err)
+			t.Fail()
+		}
+	}
+
+	if expr, err := ParseClassExpr("(front-vowel | N) \\ long"); err != nil {
+		t.Log(err)
+		t.Fail()
+	} else if classes := expr.Classes(); !slices.Equal(classes, []ClassName{"front-vowel", "N", "long"}) {
+		t.Logf("Expected classes front-vowel, N and long; got %v\n", classes)
+		t.Fail()
+	}
+}
@@ -0,0 +1,181 @@
+package alphabet
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Segment is a piece of a string produced by Alphabet.Segment. A Segment that
+// does not match any Letter of the Alphabet has a nil Letter and holds a single
+// rune of Text.
+type Segment struct {
+	// Text is the matched portion of the original string.
+	Text string
+	// Offset is the byte offset of Text in the original string.
+	Offset int
+	// Letter is the matched Letter, or nil if Text is not part of the Alphabet.
+	Letter Letter
+}
+
+// IsUpper returns true if the Segment matched the upper or titlecase form of
+// its Letter rather than the lower form.
+func (s Segment) IsUpper() bool {
+	return s.Letter != nil && s.Text != s.Letter.Lower()
+}
+
+// UnknownError is returned by Tokenize when part of a string cannot be matched
+// to any Letter.
+type UnknownError struct {
+	Input  string
+	Offset int
+	Char   rune
+}
+
+func (e *UnknownError) Error() string {
+	return fmt.Sprintf("alphabet: unknown character %q at byte offset %d of %q", e.Char, e.Offset, e.Input)
+}
+
+// AmbiguousError is returned by Tokenize when a string can be segmented into
+// Letters in more than one way. Tokenize still returns the longest-match
+// segmentation alongside it.
+type AmbiguousError struct {
+	Input  string
+	Offset int
+	// Longest is the Letter chosen by longest-match at Offset.
+	Longest Letter
+	// Alternative is a shorter Letter at Offset that also leads to a complete
+	// segmentation.
+	Alternative Letter
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf(
+		"alphabet: ambiguous segmentation at byte offset %d of %q (%q or %q)",
+		e.Offset, e.Input, e.Longest.Lower(), e.Alternative.Lower(),
+	)
+}
+
+// letterIndex maps every written form of every Letter back to its Letter, and
+// every lower form to its position in the Alphabet.
+type letterIndex struct {
+	forms   map[string]Letter
+	order   map[string]int
+	longest int
+}
+
+func newLetterIndex(letters []Letter) *letterIndex {
+	index := &letterIndex{forms: map[string]Letter{}, order: map[string]int{}}
+	for i, letter := range letters {
+		if _, ok := index.order[letter.Lower()]; !ok {
+			index.order[letter.Lower()] = i
+		}
+		for _, form := range []string{letter.Lower(), letter.Upper(), title(letter), allCaps(letter)} {
+			if len(form) == 0 {
+				continue
+			}
+			// the first Letter to claim a form keeps it
+			if _, ok := index.forms[form]; !ok {
+				index.forms[form] = letter
+			}
+			if len(form) > index.longest {
+				index.longest = len(form)
+			}
+		}
+	}
+	return index
+}
+
+// match returns the longest Letter form found at the start of s.
+func (x *letterIndex) match(s string) (Letter, int) {
+	for n := min(x.longest, len(s)); n > 0; n-- {
+		if letter, ok := x.forms[s[:n]]; ok {
+			return letter, n
+		}
+	}
+	return nil, 0
+}
+
+// segment splits s using longest-match, one rune at a time for unknown text.
+// A shorter Letter is taken instead of the longest one when it leaves less of
+// the rest of s unknown.
+func (x *letterIndex) segment(s string) []Segment {
+	unknown := x.unknowns(s)
+	segments := make([]Segment, 0, len(s))
+	for i := 0; i < len(s); {
+		var letter Letter
+		_, n := utf8.DecodeRuneInString(s[i:])
+		for m := min(x.longest, len(s)-i); m > 0; m-- {
+			if l, ok := x.forms[s[i:i+m]]; ok && unknown[i+m] == unknown[i] {
+				letter, n = l, m
+				break
+			}
+		}
+		segments = append(segments, Segment{Text: s[i : i+n], Offset: i, Letter: letter})
+		i += n
+	}
+	return segments
+}
+
+// unknowns returns the fewest runes of s[i:] that cannot be part of any Letter
+// for every i.
+func (x *letterIndex) unknowns(s string) []int {
+	unknown := make([]int, len(s)+1)
+	for i := len(s) - 1; i >= 0; i-- {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		unknown[i] = unknown[min(i+size, len(s))] + 1
+		for n := 1; n <= min(x.longest, len(s)-i); n++ {
+			if _, ok := x.forms[s[i:i+n]]; ok {
+				unknown[i] = min(unknown[i], unknown[i+n])
+			}
+		}
+	}
+	return unknown
+}
+
+// completions counts the ways s[i:] can be fully segmented into Letters for
+// every i, stopping at two since callers only care about ambiguity.
+func (x *letterIndex) completions(s string) []int {
+	ways := make([]int, len(s)+1)
+	ways[len(s)] = 1
+	for i := len(s) - 1; i >= 0; i-- {
+		for n := 1; n <= min(x.longest, len(s)-i); n++ {
+			if _, ok := x.forms[s[i:i+n]]; ok {
+				ways[i] = min(ways[i]+ways[i+n], 2)
+			}
+		}
+	}
+	return ways
+}
+
+// tokenize segments s strictly, returning an *UnknownError for unmatched text
+// and an *AmbiguousError (with the Letters) when another segmentation exists.
+func (x *letterIndex) tokenize(s string) ([]Letter, error) {
+	segments := x.segment(s)
+	letters := make([]Letter, len(segments))
+	for i, segment := range segments {
+		if segment.Letter == nil {
+			char, _ := utf8.DecodeRuneInString(segment.Text)
+			return nil, &UnknownError{Input: s, Offset: segment.Offset, Char: char}
+		}
+		letters[i] = segment.Letter
+	}
+
+	ways := x.completions(s)
+	if ways[0] < 2 {
+		return letters, nil
+	}
+	for _, segment := range segments {
+		i := segment.Offset
+		for n := 1; n < len(segment.Text); n++ {
+			if alternative, ok := x.forms[s[i:i+n]]; ok && ways[i+n] > 0 {
+				return letters, &AmbiguousError{
+					Input:       s,
+					Offset:      i,
+					Longest:     segment.Letter,
+					Alternative: alternative,
+				}
+			}
+		}
+	}
+	return letters, nil
+}
@@ -0,0 +1,425 @@
+package alphabet
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WriteTOML writes the Definition of an Alphabet to w as TOML, with declared
+// classes at the top, class shorthands in a [shorthands] table, metadata in a
+// [metadata] table and each Letter in a [[letters]] table.
+func WriteTOML(w io.Writer, a Alphabet) error {
+	definition := Define(a)
+	out := bufio.NewWriter(w)
+	sections := 0
+
+	if len(definition.Classes) > 0 {
+		fmt.Fprintf(out, "classes = %s\n", tomlArray(definition.Classes))
+		sections++
+	}
+
+	for _, table := range []struct {
+		name   string
+		values map[string]string
+	}{
+		{"shorthands", definition.Shorthands},
+		{"metadata", definition.Metadata},
+	} {
+		if len(table.values) == 0 {
+			continue
+		}
+		if sections > 0 {
+			fmt.Fprintln(out)
+		}
+		sections++
+		fmt.Fprintf(out, "[%s]\n", table.name)
+		keys := make([]string, 0, len(table.values))
+		for key := range table.values {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			fmt.Fprintf(out, "%s = %s\n", tomlKey(key), tomlString(table.values[key]))
+		}
+	}
+
+	for _, letter := range definition.Letters {
+		if sections > 0 {
+			fmt.Fprintln(out)
+		}
+		sections++
+		fmt.Fprintln(out, "[[letters]]")
+		if letter.Upper != "" {
+			fmt.Fprintf(out, "upper = %s\n", tomlString(letter.Upper))
+		}
+		fmt.Fprintf(out, "lower = %s\n", tomlString(letter.Lower))
+		if len(letter.Classes) > 0 {
+			fmt.Fprintf(out, "classes = %s\n", tomlArray(letter.Classes))
+		}
+		if letter.Features != "" {
+			fmt.Fprintf(out, "features = %s\n", tomlString(letter.Features))
+		}
+		if letter.IPA != "" {
+			fmt.Fprintf(out, "ipa = %s\n", tomlString(letter.IPA))
+		}
+		if letter.XSAMPA != "" {
+			fmt.Fprintf(out, "xsampa = %s\n", tomlString(letter.XSAMPA))
+		}
+		if letter.Native != "" {
+			fmt.Fprintf(out, "native = %s\n", tomlString(letter.Native))
+		}
+		if letter.Glyph != "" {
+			fmt.Fprintf(out, "glyph = %s\n", tomlString(letter.Glyph))
+		}
+		if len(letter.Positions) > 0 {
+			fmt.Fprintf(out, "positions = %s\n", tomlArray(letter.Positions))
+		}
+		if len(letter.Weights) > 0 {
+			fmt.Fprintf(out, "weights = %s\n", tomlWeights(letter.Weights))
+		}
+	}
+	return out.Flush()
+}
+
+// ReadTOML reads an Alphabet written by WriteTOML. Only the subset of TOML that
+// WriteTOML produces is understood: comments, the top-level classes array, the
+// [shorthands] and [metadata] tables, [[letters]] tables, string or string
+// array values, and inline tables of weights. Malformed input is reported as a
+// *DefinitionError carrying the line of the problem.
+func ReadTOML(r io.Reader) (Alphabet, error) {
+	definition, err := decodeTOML(r)
+	if err != nil {
+		return nil, err
+	}
+	return definition.Alphabet()
+}
+
+func decodeTOML(r io.Reader) (definition Definition, err error) {
+	scanner := bufio.NewScanner(r)
+	table := ""
+	// keys holds the keys of the current letter, or of the top level
+	keys := map[string]bool{}
+	for line := 1; scanner.Scan(); line++ {
+		fail := func(format string, a ...any) error {
+			return &DefinitionError{Line: line, Message: fmt.Sprintf(format, a...)}
+		}
+
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "" || strings.HasPrefix(text, "#"):
+			continue
+		case text == "[metadata]" || text == "[shorthands]":
+			table = strings.Trim(text, "[]")
+			values := &definition.Metadata
+			if table == "shorthands" {
+				values = &definition.Shorthands
+			}
+			if *values != nil {
+				return definition, fail("duplicate table %s", text)
+			}
+			*values = map[string]string{}
+			continue
+		case text == "[[letters]]":
+			definition.Letters = append(definition.Letters, LetterDefinition{Line: line})
+			table = "letters"
+			keys = map[string]bool{}
+			continue
+		case strings.HasPrefix(text, "["):
+			return definition, fail("unknown table %s", text)
+		}
+
+		key, rest, err := parseTOMLKey(text)
+		if err != nil {
+			return definition, fail("%s", err)
+		}
+		rest = strings.TrimSpace(rest)
+		if !strings.HasPrefix(rest, "=") {
+			return definition, fail("expected '=' after key %q", key)
+		}
+		rest = strings.TrimSpace(rest[1:])
+
+		switch table {
+		case "metadata", "shorthands":
+			values := definition.Metadata
+			if table == "shorthands" {
+				values = definition.Shorthands
+			}
+			value, err := parseTOMLValue(rest)
+			if err != nil {
+				return definition, fail("%s", err)
+			}
+			if _, ok := values[key]; ok {
+				return definition, fail("duplicate key %q", key)
+			}
+			values[key] = value
+			if table == "shorthands" {
+				if definition.ShorthandLines == nil {
+					definition.ShorthandLines = map[string]int{}
+				}
+				definition.ShorthandLines[key] = line
+			}
+		case "letters":
+			if keys[key] {
+				return definition, fail("duplicate key %q", key)
+			}
+			keys[key] = true
+			letter := &definition.Letters[len(definition.Letters)-1]
+			switch key {
+			case "upper":
+				letter.Upper, err = parseTOMLValue(rest)
+			case "lower":
+				letter.Lower, err = parseTOMLValue(rest)
+			case "classes":
+				letter.Classes, err = parseTOMLArray(rest)
+			case "features":
+				letter.Features, err = parseTOMLValue(rest)
+			case "ipa":
+				letter.IPA, err = parseTOMLValue(rest)
+			case "xsampa":
+				letter.XSAMPA, err = parseTOMLValue(rest)
+			case "native":
+				letter.Native, err = parseTOMLValue(rest)
+			case "glyph":
+				letter.Glyph, err = parseTOMLValue(rest)
+			case "positions":
+				letter.Positions, err = parseTOMLArray(rest)
+			case "weights":
+				letter.Weights, err = parseTOMLWeights(rest)
+			default:
+				err = fmt.Errorf("unknown letter key %q", key)
+			}
+			if err != nil {
+				return definition, fail("%s", err)
+			}
+		default:
+			if key != "classes" {
+				return definition, fail("unknown top-level key %q", key)
+			}
+			if keys[key] {
+				return definition, fail("duplicate key %q", key)
+			}
+			keys[key] = true
+			definition.ClassesLine = line
+			if definition.Classes, err = parseTOMLArray(rest); err != nil {
+				return definition, fail("%s", err)
+			}
+		}
+	}
+	return definition, scanner.Err()
+}
+
+// parseTOMLKey splits a bare or quoted key from the rest of the line.
+func parseTOMLKey(text string) (key, rest string, err error) {
+	if strings.HasPrefix(text, `"`) || strings.HasPrefix(text, "'") {
+		return parseTOMLString(text)
+	}
+	end := strings.IndexFunc(text, func(r rune) bool { return !isBareKeyRune(r) })
+	if end == 0 {
+		return "", "", fmt.Errorf("expected a key, got %q", text)
+	}
+	if end < 0 {
+		end = len(text)
+	}
+	return text[:end], text[end:], nil
+}
+
+// parseTOMLValue parses a single string value followed by an optional comment.
+func parseTOMLValue(text string) (string, error) {
+	value, rest, err := parseTOMLString(text)
+	if err != nil {
+		return "", err
+	}
+	return value, expectTOMLEnd(rest)
+}
+
+// parseTOMLArray parses a single line array of strings.
+func parseTOMLArray(text string) (values []string, err error) {
+	if !strings.HasPrefix(text, "[") {
+		return nil, fmt.Errorf("expected an array, got %q", text)
+	}
+	rest := strings.TrimSpace(text[1:])
+	for !strings.HasPrefix(rest, "]") {
+		var value string
+		if value, rest, err = parseTOMLString(rest); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		rest = strings.TrimSpace(rest)
+		if strings.HasPrefix(rest, ",") {
+			rest = strings.TrimSpace(rest[1:])
+		} else if !strings.HasPrefix(rest, "]") {
+			return nil, fmt.Errorf("expected ',' or ']' in array, got %q", rest)
+		}
+	}
+	return values, expectTOMLEnd(rest[1:])
+}
+
+// parseTOMLWeights parses a single line inline table of numbers, such as
+// { C = 3, V = 0.5 }.
+func parseTOMLWeights(text string) (weights map[string]float64, err error) {
+	if !strings.HasPrefix(text, "{") {
+		return nil, fmt.Errorf("expected an inline table, got %q", text)
+	}
+	weights = map[string]float64{}
+	rest := strings.TrimSpace(text[1:])
+	for !strings.HasPrefix(rest, "}") {
+		var key string
+		if key, rest, err = parseTOMLKey(rest); err != nil {
+			return nil, err
+		}
+		rest = strings.TrimSpace(rest)
+		if !strings.HasPrefix(rest, "=") {
+			return nil, fmt.Errorf("expected '=' after key %q", key)
+		}
+		rest = strings.TrimSpace(rest[1:])
+		end := strings.IndexAny(rest, ",} \t")
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated inline table %s", text)
+		}
+		weight, err := strconv.ParseFloat(rest[:end], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", rest[:end])
+		}
+		if _, ok := weights[key]; ok {
+			return nil, fmt.Errorf("duplicate key %q", key)
+		}
+		weights[key] = weight
+		rest = strings.TrimSpace(rest[end:])
+		if strings.HasPrefix(rest, ",") {
+			rest = strings.TrimSpace(rest[1:])
+		} else if !strings.HasPrefix(rest, "}") {
+			return nil, fmt.Errorf("expected ',' or '}' in inline table, got %q", rest)
+		}
+	}
+	return weights, expectTOMLEnd(rest[1:])
+}
+
+// parseTOMLString parses a basic or literal string at the start of text.
+func parseTOMLString(text string) (value, rest string, err error) {
+	switch {
+	case strings.HasPrefix(text, "'"):
+		end := strings.IndexByte(text[1:], '\'')
+		if end < 0 {
+			return "", "", fmt.Errorf("unterminated string %s", text)
+		}
+		return text[1 : end+1], text[end+2:], nil
+	case strings.HasPrefix(text, `"`):
+		var b strings.Builder
+		for i := 1; i < len(text); i++ {
+			switch text[i] {
+			case '\\':
+				n, err := unescapeTOML(&b, text[i:])
+				if err != nil {
+					return "", "", err
+				}
+				i += n - 1
+			case '"':
+				return b.String(), text[i+1:], nil
+			default:
+				b.WriteByte(text[i])
+			}
+		}
+		return "", "", fmt.Errorf("unterminated string %s", text)
+	}
+	return "", "", fmt.Errorf("expected a string, got %q", text)
+}
+
+// tomlEscapes are the single character escapes of TOML basic strings.
+var tomlEscapes = map[byte]rune{'b': '\b', 't': '\t', 'n': '\n', 'f': '\f', 'r': '\r', '"': '"', '\\': '\\'}
+
+// unescapeTOML writes the character of the escape sequence at the start of
+// text and returns its length. Only the escapes TOML allows are accepted.
+func unescapeTOML(b *strings.Builder, text string) (int, error) {
+	if len(text) < 2 {
+		return 0, fmt.Errorf("unterminated string")
+	}
+	if r, ok := tomlEscapes[text[1]]; ok {
+		b.WriteRune(r)
+		return 2, nil
+	}
+	digits := map[byte]int{'u': 4, 'U': 8}[text[1]]
+	if digits == 0 {
+		return 0, fmt.Errorf("invalid escape %q", text[:2])
+	}
+	if len(text) < 2+digits {
+		return 0, fmt.Errorf("invalid escape %q", text)
+	}
+	code, err := strconv.ParseUint(text[2:2+digits], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(code)) {
+		return 0, fmt.Errorf("invalid escape %q", text[:2+digits])
+	}
+	b.WriteRune(rune(code))
+	return 2 + digits, nil
+}
+
+func expectTOMLEnd(rest string) error {
+	if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+		return fmt.Errorf("unexpected %q after value", rest)
+	}
+	return nil
+}
+
+func isBareKeyRune(r rune) bool {
+	return r == '_' || r == '-' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+func tomlKey(key string) string {
+	if key != "" && strings.IndexFunc(key, func(r rune) bool { return !isBareKeyRune(r) }) < 0 {
+		return key
+	}
+	return tomlString(key)
+}
+
+func tomlArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = tomlString(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func tomlWeights(weights map[string]float64) string {
+	keys := make([]string, 0, len(weights))
+	for key := range weights {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = tomlKey(key) + " = " + strconv.FormatFloat(weights[key], 'g', -1, 64)
+	}
+	return "{ " + strings.Join(pairs, ", ") + " }"
+}
+
+// tomlString quotes s as a TOML basic string.
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if unicode.IsControl(r) {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,146 @@
+package alphabet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiagnosticKind classifies a problem found by Alphabet.Validate.
+type DiagnosticKind int
+
+const (
+	// Duplicate means several Letters share a lower form.
+	Duplicate DiagnosticKind = iota + 1
+	// CaseCollision means an upper form of one Letter is a form of another.
+	CaseCollision
+	// AmbiguousSegmentation means some string can be split into Letters in
+	// more than one way.
+	AmbiguousSegmentation
+	// EmptyClass means a Class declared with WithClasses has no Letters.
+	EmptyClass
+	// Unclassified means a Letter belongs to no Class.
+	Unclassified
+	// NativeCollision means several Letters share a codepoint of their native
+	// forms.
+	NativeCollision
+)
+
+// Diagnostic describes a single problem with an Alphabet.
+type Diagnostic struct {
+	Kind DiagnosticKind
+	// Letters holds the Letters involved, if any.
+	Letters []Letter
+	// Class holds the Class of an EmptyClass Diagnostic.
+	Class Class
+	// Example holds a string that segments ambiguously, or the codepoint
+	// shared by a NativeCollision.
+	Example string
+}
+
+func (d Diagnostic) String() string {
+	forms := make([]string, len(d.Letters))
+	for i, letter := range d.Letters {
+		forms[i] = fmt.Sprintf("%q", letter.Lower())
+	}
+	letters := strings.Join(forms, ", ")
+
+	switch d.Kind {
+	case Duplicate:
+		return fmt.Sprintf("duplicate letters %s", letters)
+	case CaseCollision:
+		return fmt.Sprintf("case collision between letters %s", letters)
+	case AmbiguousSegmentation:
+		return fmt.Sprintf("ambiguous segmentation of %q using letters %s", d.Example, letters)
+	case EmptyClass:
+		return fmt.Sprintf("class %q has no letters", d.Class)
+	case Unclassified:
+		return fmt.Sprintf("letter %s has no class", letters)
+	case NativeCollision:
+		return fmt.Sprintf("letters %s share the native codepoint %s", letters, FormatCodepoints(d.Example, " "))
+	}
+	return "???"
+}
+
+func (b basicAlphabet) Validate() (diagnostics []Diagnostic) {
+	letters := b.ToSlice()
+
+	// duplicates and case collisions
+	byLower := map[string]int{}
+	for i, letter := range letters {
+		if j, ok := byLower[letter.Lower()]; ok {
+			diagnostics = append(diagnostics, Diagnostic{Kind: Duplicate, Letters: []Letter{letters[j], letter}})
+			continue
+		}
+		byLower[letter.Lower()] = i
+	}
+	for i, letter := range letters {
+		for _, form := range []string{letter.Upper(), title(letter), allCaps(letter)} {
+			other, ok := b.index.forms[form]
+			if ok && other.Lower() != letter.Lower() {
+				diagnostics = append(diagnostics, Diagnostic{Kind: CaseCollision, Letters: []Letter{letters[i], other}})
+				break
+			}
+		}
+	}
+
+	// ambiguous segmentations, first within single letters, then across pairs
+	reported := map[string]bool{}
+	report := func(example string) {
+		_, err := b.index.tokenize(example)
+		if ambiguity, ok := err.(*AmbiguousError); ok && !reported[example] {
+			reported[example] = true
+			diagnostics = append(diagnostics, Diagnostic{
+				Kind:    AmbiguousSegmentation,
+				Letters: []Letter{ambiguity.Longest, ambiguity.Alternative},
+				Example: example,
+			})
+		}
+	}
+	for _, letter := range letters {
+		if b.index.completions(letter.Lower())[0] > 1 {
+			report(letter.Lower())
+		}
+	}
+	for _, x := range letters {
+		for _, y := range letters {
+			if reported[x.Lower()] || reported[y.Lower()] {
+				continue
+			}
+			pair := x.Lower() + y.Lower()
+			if _, isLetter := b.index.forms[pair]; !isLetter && b.index.completions(pair)[0] > 1 {
+				report(pair)
+			}
+		}
+	}
+
+	// classes
+	for _, class := range b.classes {
+		if b.GetLettersByClass(class).Len() == 0 {
+			diagnostics = append(diagnostics, Diagnostic{Kind: EmptyClass, Class: class})
+		}
+	}
+	for _, letter := range letters {
+		if len(letter.GetClassMap()) == 0 {
+			diagnostics = append(diagnostics, Diagnostic{Kind: Unclassified, Letters: []Letter{letter}})
+		}
+	}
+
+	// native forms, compared codepoint by codepoint so that Romanize can
+	// always tell which Letter a codepoint belongs to
+	byCodepoint := map[rune]int{}
+	for i, letter := range letters {
+		reported := map[int]bool{}
+		for _, r := range letter.Native() {
+			j, ok := byCodepoint[r]
+			if !ok {
+				byCodepoint[r] = i
+				continue
+			}
+			if j != i && !reported[j] {
+				reported[j] = true
+				diagnostics = append(diagnostics, Diagnostic{Kind: NativeCollision, Letters: []Letter{letters[j], letter}, Example: string(r)})
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,43 @@
+package alphabet
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	a := New([]Letter{
+		NewLetter("A", "a", 'V'),
+		NewLetter("N", "n", 'C'),
+		NewLetter("G", "g", 'C'),
+		NewLetter("NG", "ng", 'C'),
+		NewLetter("A", "a", 'V'),
+		NewLetter("N", "ŋ", 'C'),
+		NewLetter("'", "'"),
+	}, WithClasses('C', 'V', 'L'))
+
+	counts := map[DiagnosticKind]int{}
+	for _, diagnostic := range a.Validate() {
+		t.Log(diagnostic)
+		counts[diagnostic.Kind]++
+		if diagnostic.Kind == AmbiguousSegmentation && diagnostic.Example != "ng" {
+			t.Logf("Expected the ambiguous example to be \"ng\"; got %q\n", diagnostic.Example)
+			t.Fail()
+		}
+	}
+
+	for kind, count := range map[DiagnosticKind]int{
+		Duplicate:             1,
+		CaseCollision:         1,
+		AmbiguousSegmentation: 1,
+		EmptyClass:            1,
+		Unclassified:          1,
+	} {
+		if counts[kind] != count {
+			t.Logf("Expected %d diagnostics of kind %d; got %d\n", count, kind, counts[kind])
+			t.Fail()
+		}
+	}
+
+	if diagnostics := testAlphabet().Validate(); len(diagnostics) != 3 {
+		t.Logf("Expected only the \"ch\", \"sh\" and \"tch\" ambiguities; got %v\n", diagnostics)
+		t.Fail()
+	}
+}
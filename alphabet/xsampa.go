@@ -0,0 +1,59 @@
+package alphabet
+
+import (
+	"slices"
+	"strings"
+)
+
+// xsampaTable pairs IPA symbols with their X-SAMPA spellings. Symbols missing
+// from the table, including plain ASCII letters, are spelled the same in both.
+var xsampaTable = []struct{ ipa, xsampa string }{
+	// consonants
+	{"ʈ", "t`"}, {"ɖ", "d`"}, {"ɟ", "J\\"}, {"ɡ", "g"}, {"ɢ", "G\\"}, {"ʔ", "?"},
+	{"ɱ", "F"}, {"ɳ", "n`"}, {"ɲ", "J"}, {"ŋ", "N"}, {"ɴ", "N\\"},
+	{"ʙ", "B\\"}, {"ʀ", "R\\"}, {"ɾ", "4"}, {"ɽ", "r`"},
+	{"ɸ", "p\\"}, {"β", "B"}, {"θ", "T"}, {"ð", "D"}, {"ʃ", "S"}, {"ʒ", "Z"},
+	{"ʂ", "s`"}, {"ʐ", "z`"}, {"ç", "C"}, {"ʝ", "j\\"}, {"ɣ", "G"}, {"χ", "X"},
+	{"ʁ", "R"}, {"ħ", "X\\"}, {"ʕ", "?\\"}, {"ɦ", "h\\"}, {"ɬ", "K"}, {"ɮ", "K\\"},
+	{"ʋ", "P"}, {"ɹ", "r\\"}, {"ɻ", "r\\`"}, {"ɰ", "M\\"},
+	{"ɭ", "l`"}, {"ʎ", "L"}, {"ʟ", "L\\"},
+	// vowels
+	{"ɨ", "1"}, {"ʉ", "}"}, {"ɯ", "M"}, {"ɪ", "I"}, {"ʏ", "Y"}, {"ʊ", "U"},
+	{"ø", "2"}, {"ɘ", "@\\"}, {"ɵ", "8"}, {"ɤ", "7"}, {"ə", "@"},
+	{"ɛ", "E"}, {"œ", "9"}, {"ɜ", "3"}, {"ɞ", "3\\"}, {"ʌ", "V"}, {"ɔ", "O"},
+	{"æ", "{"}, {"ɐ", "6"}, {"ɶ", "&"}, {"ɑ", "A"}, {"ɒ", "Q"},
+	// suprasegmentals and diacritics
+	{"ː", ":"}, {"ˈ", "\""}, {"ˌ", "%"}, {"ʰ", "_h"}, {"ʷ", "_w"}, {"ʲ", "'"},
+	{"̃", "~"}, {"̥", "_0"}, {"̬", "_v"}, {"̩", "="}, {"͡", "_"},
+}
+
+var (
+	ipaToXSAMPA = strings.NewReplacer(xsampaPairs(false)...)
+	xsampaToIPA = strings.NewReplacer(xsampaPairs(true)...)
+)
+
+// xsampaPairs returns the table as strings.NewReplacer arguments, longest
+// source first so that "r\`" wins over "r\" and "_h" over "_".
+func xsampaPairs(reverse bool) []string {
+	table := slices.Clone(xsampaTable)
+	if reverse {
+		for i := range table {
+			table[i].ipa, table[i].xsampa = table[i].xsampa, table[i].ipa
+		}
+	}
+	slices.SortStableFunc(table, func(a, b struct{ ipa, xsampa string }) int {
+		return len(b.ipa) - len(a.ipa)
+	})
+
+	pairs := make([]string, 0, 2*len(table))
+	for _, pair := range table {
+		pairs = append(pairs, pair.ipa, pair.xsampa)
+	}
+	return pairs
+}
+
+// XSAMPA converts an IPA transcription to X-SAMPA.
+func XSAMPA(ipa string) string { return ipaToXSAMPA.Replace(ipa) }
+
+// FromXSAMPA converts an X-SAMPA transcription to IPA.
+func FromXSAMPA(xsampa string) string { return xsampaToIPA.Replace(xsampa) }
@@ -23,6 +23,11 @@ type Collection[T comparable] interface {
 	// GetRandom returns a single randomly chosen value from the Collection. If
 	// the Collection is empty, a null value of type T is returned.
 	GetRandom() T
+	// GetWeightedRandom returns a single value chosen with probability
+	// proportional to its weight. Values with a weight of zero or less are
+	// never chosen. If no value can be chosen, a null value of type T is
+	// returned.
+	GetWeightedRandom(func(T) float64) T
 	// ToShuffledList returns a random permutation of the Collection as List[T].
 	ToShuffledList() List[T]
 	// ToSortedList takes a sort function and returns a sorted List[T].
@@ -101,6 +106,32 @@ func (l List[T]) GetRandom() (chosen T) {
 	return
 }
 
+// GetWeightedRandom returns a random type T from the List with probability
+// proportional to the weight the given function returns for it.
+func (l List[T]) GetWeightedRandom(weight func(T) float64) (chosen T) {
+	weights := make([]float64, len(l))
+	total := 0.0
+	for i, item := range l {
+		weights[i] = max(weight(item), 0)
+		total += weights[i]
+	}
+	if total <= 0 {
+		return
+	}
+
+	n := rand.Float64() * total
+	for i, item := range l {
+		if weights[i] <= 0 {
+			continue
+		}
+		chosen = item
+		if n -= weights[i]; n < 0 {
+			break
+		}
+	}
+	return
+}
+
 // ToShuffledList returns the List[T] back with its values shuffled.
 func (l List[T]) ToShuffledList() (shuffled List[T]) {
 	size := len(l)
@@ -207,6 +238,12 @@ func (s Set[T]) GetRandom() (chosen T) {
 	return
 }
 
+// GetWeightedRandom converts the Set to a List and gets a weighted random T
+// from it.
+func (s Set[T]) GetWeightedRandom(weight func(T) float64) T {
+	return s.ToList().GetWeightedRandom(weight)
+}
+
 // ToShuffledList converts the Set to a List and shuffles it.
 func (s Set[T]) ToShuffledList() List[T] { return s.ToList().ToShuffledList() }
 
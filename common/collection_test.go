@@ -119,3 +119,28 @@ func TestShuffledLists(t *testing.T) {
 		t.Logf("%c\n", shuffledSet)
 	}
 }
+
+func TestWeightedRandom(t *testing.T) {
+	list := CollectionFrom[rune]([]rune{'a', 'b', 'c'})
+	counts := map[rune]int{}
+	for i := 0; i < 3000; i++ {
+		counts[list.GetWeightedRandom(func(r rune) float64 {
+			return map[rune]float64{'a': 8, 'b': 2, 'c': 0}[r]
+		})]++
+	}
+	if counts['c'] > 0 || counts['a'] < 2*counts['b'] || counts['b'] == 0 {
+		t.Logf("Expected roughly four times as many 'a' as 'b' and no 'c'; got %v\n", counts)
+		t.Fail()
+	}
+
+	if chosen := list.ToSet().GetWeightedRandom(func(rune) float64 { return 0 }); chosen != 0 {
+		t.Logf("Expected nothing to be chosen when all weights are zero; got %c\n", chosen)
+		t.Fail()
+	}
+
+	weights := ZipfWeights(4, 1)
+	if !slices.Equal(weights, []float64{1, 0.5, 1.0 / 3, 0.25}) {
+		t.Logf("Expected Zipf weights 1, 1/2, 1/3, 1/4; got %v\n", weights)
+		t.Fail()
+	}
+}
@@ -0,0 +1,14 @@
+package common
+
+import "math"
+
+// ZipfWeights returns n weights following Zipf's law, where the weight of the
+// item at rank k (starting at 1) is 1/k^exponent. An exponent of 1 gives the
+// classic distribution; larger exponents favour the first items more.
+func ZipfWeights(n int, exponent float64) []float64 {
+	weights := make([]float64, max(n, 0))
+	for i := range weights {
+		weights[i] = 1 / math.Pow(float64(i+1), exponent)
+	}
+	return weights
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/jack-reeser/conlang/alphabet"
@@ -17,36 +18,37 @@ const (
 func main() {
 	fmt.Println("An example of a simple alphabet...")
 
-	// parse in a simple alphabet
+	// parse in a simple alphabet, with each letter's frequency in percent and
+	// optionally the only positions it may occur in
 	inputLetters := map[alphabet.Class][]string{
 		VOWEL: {
-			"A,a",
-			"E,e",
-			"I,i",
-			"O,o",
-			"U,u",
+			"A,a,8.2",
+			"E,e,12.7",
+			"I,i,7.0",
+			"O,o,7.5",
+			"U,u,2.8",
 		},
 		CONSONANT: {
-			"B,b",
-			"C,c",
-			"D,d",
-			"F,f",
-			"G,g",
-			"H,h",
-			"J,j",
-			"K,k",
-			"L,l",
-			"M,m",
-			"N,n",
-			"P,p",
-			"Q,q",
-			"R,r",
-			"S,s",
-			"T,t",
-			"V,v",
-			"W,w",
-			"X,x",
-			"Z,z",
+			"B,b,1.5",
+			"C,c,2.8",
+			"D,d,4.3",
+			"F,f,2.2",
+			"G,g,2.0",
+			"H,h,6.1,initial medial",
+			"J,j,0.15,initial medial",
+			"K,k,0.77",
+			"L,l,4.0",
+			"M,m,2.4",
+			"N,n,6.7",
+			"P,p,1.9",
+			"Q,q,0.1,initial",
+			"R,r,6.0",
+			"S,s,6.3",
+			"T,t,9.1",
+			"V,v,1.0",
+			"W,w,2.4",
+			"X,x,0.15",
+			"Z,z,0.07",
 		},
 	}
 
@@ -54,12 +56,23 @@ func main() {
 
 	addLetter := func(class alphabet.Class, csvList []string) {
 		for _, csv := range csvList {
-			if values := strings.Split(csv, COMMA); len(values) == 2 {
-				letters = append(
-					letters,
-					alphabet.NewLetter(values[0], values[1], class),
-				)
+			values := strings.Split(csv, COMMA)
+			if len(values) < 3 {
+				continue
 			}
+			weight, err := strconv.ParseFloat(values[2], 64)
+			if err != nil {
+				continue
+			}
+			letter := alphabet.WithWeight(alphabet.NewLetter(values[0], values[1], class), class, weight)
+			if len(values) == 4 {
+				positions, err := alphabet.ParsePositions(strings.Fields(values[3])...)
+				if err != nil {
+					continue
+				}
+				letter = alphabet.WithPositions(letter, positions)
+			}
+			letters = append(letters, letter)
 		}
 	}
 
@@ -78,23 +91,26 @@ func main() {
 
 	fmt.Printf("Got %d consonants and %d vowels\n", classMap[CONSONANT].Len(), classMap[VOWEL].Len())
 
-	// make a function to get a random word using a pattern
+	// make a function to get a random word using a pattern, choosing common
+	// letters more often than rare ones
 	getRandomWord := func(pattern string) string {
 		var randomWord string
-		for i, class := range alphabet.StringToClasses(pattern) {
-			var letter alphabet.Letter
-			if list, ok := classMap[class]; ok {
-				letter = list.GetRandom()
-			} else {
-				letter = alphabet.NewLetter("?", "?", '?')
-			}
+		classes := alphabet.StringToClasses(pattern)
+		for i, class := range classes {
+			position := alphabet.Medial
 			if i == 0 {
-				randomWord = letter.Upper()
+				position = alphabet.Initial
+			}
+			if i == len(classes)-1 {
+				position = position&alphabet.Initial | alphabet.Final
+			}
+			if _, ok := classMap[class]; ok {
+				randomWord += simpleAlphabet.GetRandomLetterAt(class, position).Lower()
 			} else {
-				randomWord = randomWord + letter.Lower()
+				randomWord += "?"
 			}
 		}
-		return randomWord
+		return alphabet.ToTitle(simpleAlphabet, randomWord)
 	}
 
 	fmt.Println("Generated random words:")
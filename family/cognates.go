@@ -0,0 +1,60 @@
+package family
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CognateTable lays out Reflexes with a row per Entry and a column per
+// Language.
+type CognateTable struct {
+	Languages []string
+	Rows      []CognateRow
+}
+
+// CognateRow holds the reflexes of one Entry, one per Language of its table.
+type CognateRow struct {
+	Entry Entry
+	Forms []string
+}
+
+// Cognates builds a CognateTable from the Reflexes returned by Derive, keeping
+// their order of Languages and Entries.
+func Cognates(reflexes []Reflex) CognateTable {
+	table := CognateTable{}
+	columns := map[string]int{}
+	rows := map[Entry]int{}
+	for _, reflex := range reflexes {
+		if _, ok := columns[reflex.Language]; !ok {
+			columns[reflex.Language] = len(table.Languages)
+			table.Languages = append(table.Languages, reflex.Language)
+		}
+		if _, ok := rows[reflex.Entry]; !ok {
+			rows[reflex.Entry] = len(table.Rows)
+			table.Rows = append(table.Rows, CognateRow{Entry: reflex.Entry})
+		}
+	}
+	for i := range table.Rows {
+		table.Rows[i].Forms = make([]string, len(table.Languages))
+	}
+	for _, reflex := range reflexes {
+		table.Rows[rows[reflex.Entry]].Forms[columns[reflex.Language]] = reflex.Form
+	}
+	return table
+}
+
+// WriteCSV writes the table as CSV with a header row. The first column holds
+// the gloss of each Entry; its proto-form is in the proto-language's column.
+func (c CognateTable) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(append([]string{"gloss"}, c.Languages...)); err != nil {
+		return err
+	}
+	for _, row := range c.Rows {
+		if err := out.Write(append([]string{row.Entry.Gloss}, row.Forms...)); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
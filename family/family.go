@@ -0,0 +1,168 @@
+// Package family models a language family as a tree of languages descended
+// from a proto-language through ordered stages of sound changes, and derives
+// every descendant's words from the proto-language's lexicon.
+package family
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/soundchange"
+)
+
+// Stage is a named set of sound changes applied together.
+type Stage struct {
+	Name    string
+	Changes *soundchange.Applier
+}
+
+// Language is a node of a family tree. The words of a Language are those of
+// its parent after each of its Stages, in order. A Stage's Applier must be able
+// to read the forms its parent spells, so it is usually made for an Alphabet
+// holding the Letters of both. If the Language has an Alphabet, each of its
+// words must be spelled in it.
+type Language struct {
+	Name     string
+	Alphabet alphabet.Alphabet
+	Stages   []Stage
+	Children []*Language
+}
+
+// Branch adds descendants to the Language and returns it.
+func (l *Language) Branch(children ...*Language) *Language {
+	l.Children = append(l.Children, children...)
+	return l
+}
+
+// Languages returns the Language and all its descendants, parents first.
+func (l *Language) Languages() []*Language {
+	languages := []*Language{l}
+	for _, child := range l.Children {
+		languages = append(languages, child.Languages()...)
+	}
+	return languages
+}
+
+// Entry is a word of the proto-language.
+type Entry struct {
+	Gloss string
+	Form  string
+}
+
+// Tree is a proto-language with its lexicon.
+type Tree struct {
+	Proto   *Language
+	Lexicon []Entry
+}
+
+// Form is a word as it stood after a Stage.
+type Form struct {
+	Language string
+	Stage    string
+	Form     string
+	// Steps are the sound changes of the Stage that changed the word.
+	Steps []soundchange.Step
+}
+
+// Reflex is the descendant of a proto-language Entry in one Language.
+type Reflex struct {
+	Language string
+	Entry    Entry
+	Form     string
+	// History holds the word after every Stage from the proto-language down
+	// to the Language, in order.
+	History []Form
+}
+
+// Error is returned by Derive when a word cannot be derived.
+type Error struct {
+	Language string
+	Stage    string
+	Entry    Entry
+	Err      error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("family: deriving %q (%s) in %s, stage %q: %s", e.Entry.Form, e.Entry.Gloss, e.Language, e.Stage, e.Err)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Derive derives every Entry of the lexicon in every Language of the Tree,
+// including the proto-language itself. Reflexes are grouped by Language,
+// parents first, and listed in lexicon order within each. It returns an error
+// if Language names are not unique, a Stage has no Changes, a Stage cannot
+// read a word or a word is not spelled in the Alphabet of its Language.
+func (t Tree) Derive() ([]Reflex, error) {
+	seen := map[string]bool{}
+	for _, language := range t.Proto.Languages() {
+		if seen[language.Name] {
+			return nil, fmt.Errorf("family: duplicate language %q", language.Name)
+		}
+		seen[language.Name] = true
+		for _, stage := range language.Stages {
+			if stage.Changes == nil {
+				return nil, fmt.Errorf("family: stage %q of %s has no changes", stage.Name, language.Name)
+			}
+		}
+	}
+
+	reflexes := []Reflex{}
+	var derive func(l *Language, parents []Reflex) error
+	derive = func(l *Language, parents []Reflex) error {
+		derived := make([]Reflex, len(parents))
+		for i, parent := range parents {
+			reflex := Reflex{
+				Language: l.Name,
+				Entry:    parent.Entry,
+				Form:     parent.Form,
+				History:  parent.History[:len(parent.History):len(parent.History)],
+			}
+			stage := ""
+			for _, s := range l.Stages {
+				form, steps, err := s.Changes.Apply(reflex.Form)
+				if err != nil {
+					return &Error{Language: l.Name, Stage: s.Name, Entry: reflex.Entry, Err: err}
+				}
+				stage = s.Name
+				reflex.Form = form
+				reflex.History = append(reflex.History, Form{Language: l.Name, Stage: s.Name, Form: form, Steps: steps})
+			}
+			if err := l.spell(reflex.Form); err != nil {
+				return &Error{Language: l.Name, Stage: stage, Entry: reflex.Entry, Err: err}
+			}
+			derived[i] = reflex
+		}
+		reflexes = append(reflexes, derived...)
+		for _, child := range l.Children {
+			if err := derive(child, derived); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	proto := make([]Reflex, len(t.Lexicon))
+	for i, entry := range t.Lexicon {
+		proto[i] = Reflex{Entry: entry, Form: entry.Form, History: []Form{}}
+	}
+	if err := derive(t.Proto, proto); err != nil {
+		return nil, err
+	}
+	return reflexes, nil
+}
+
+// spell checks that a word is spelled in the Alphabet of the Language, if it
+// has one.
+func (l *Language) spell(form string) error {
+	if l.Alphabet == nil {
+		return nil
+	}
+	_, err := l.Alphabet.Tokenize(form)
+	var ambiguous *alphabet.AmbiguousError
+	if errors.As(err, &ambiguous) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,113 @@
+package family
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/soundchange"
+)
+
+func TestDerive(t *testing.T) {
+	letters := []alphabet.Letter{}
+	for _, vowel := range strings.Split("a e i o u", " ") {
+		letters = append(letters, alphabet.NewLetter(strings.ToUpper(vowel), vowel, 'V'))
+	}
+	for _, consonant := range strings.Split("p b t d k g f v s h m n r", " ") {
+		letters = append(letters, alphabet.NewLetter(strings.ToUpper(consonant), consonant, 'C'))
+	}
+	a := alphabet.New(letters)
+
+	stage := func(name, rules string) Stage {
+		changes, err := soundchange.Parse(a, strings.NewReader(rules))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return Stage{Name: name, Changes: changes}
+	}
+
+	western := &Language{Name: "Proto-West", Alphabet: a, Stages: []Stage{stage("lenition", "p, t, k > b, d, g / V_V")}}
+	western.Branch(
+		&Language{Name: "Coastal", Alphabet: a, Stages: []Stage{
+			stage("spirantization", "b, d, g > v, s, h / V_V"),
+			stage("apocope", "V > 0 / C_#"),
+		}},
+		&Language{Name: "Highland", Alphabet: a},
+	)
+	tree := Tree{
+		Proto: (&Language{Name: "Proto", Alphabet: a}).Branch(
+			western,
+			&Language{Name: "Eastern", Alphabet: a, Stages: []Stage{stage("nasalization", "p > m / #_")}},
+		),
+		Lexicon: []Entry{{"water", "pata"}, {"stone", "Kupa"}, {"fire", "imi"}},
+	}
+
+	reflexes, err := tree.Derive()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reflexes) != 5*3 {
+		t.Logf("Expected 15 reflexes; got %d\n", len(reflexes))
+		t.Fail()
+	}
+
+	for _, reflex := range reflexes {
+		if reflex.Language != "Coastal" || reflex.Entry.Gloss != "water" {
+			continue
+		}
+		history := []string{}
+		for _, form := range reflex.History {
+			history = append(history, form.Language+"/"+form.Stage+":"+form.Form)
+		}
+		expected := "Proto-West/lenition:pada Coastal/spirantization:pasa Coastal/apocope:pas"
+		if reflex.Form != "pas" || strings.Join(history, " ") != expected {
+			t.Logf("Expected Coastal \"water\" to be \"pas\" via %q; got %q via %q\n", expected, reflex.Form, history)
+			t.Fail()
+		}
+	}
+
+	var csv strings.Builder
+	if err := Cognates(reflexes).WriteCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+	expected := `gloss,Proto,Proto-West,Coastal,Highland,Eastern
+water,pata,pada,pas,pada,mata
+stone,Kupa,kuba,kuv,kuba,kupa
+fire,imi,imi,im,imi,imi
+`
+	if csv.String() != expected {
+		t.Logf("Expected cognate table\n%s\ngot\n%s\n", expected, csv.String())
+		t.Fail()
+	}
+
+	var derivationError *Error
+	highland := western.Children[1]
+	highland.Alphabet = alphabet.New(slices.DeleteFunc(slices.Clone(letters), func(l alphabet.Letter) bool { return l.Lower() == "d" }))
+	if _, err := tree.Derive(); !errors.As(err, &derivationError) || derivationError.Language != "Highland" {
+		t.Logf("Expected an error spelling \"pada\" in Highland; got %v\n", err)
+		t.Fail()
+	}
+	highland.Alphabet = a
+
+	tree.Lexicon = append(tree.Lexicon, Entry{"sky", "ŋa"})
+	if _, err := tree.Derive(); !errors.As(err, &derivationError) || derivationError.Language != "Proto" {
+		t.Logf("Expected an error spelling \"ŋa\" in Proto; got %v\n", err)
+		t.Fail()
+	}
+	tree.Lexicon = tree.Lexicon[:len(tree.Lexicon)-1]
+
+	highland.Stages = []Stage{{Name: "nothing"}}
+	if _, err := tree.Derive(); err == nil {
+		t.Log("Expected an error for a stage without changes")
+		t.Fail()
+	}
+	highland.Stages = nil
+
+	tree.Proto.Branch(&Language{Name: "Coastal"})
+	if _, err := tree.Derive(); err == nil {
+		t.Log("Expected an error for duplicate language names")
+		t.Fail()
+	}
+}
@@ -0,0 +1,208 @@
+// Package glyph lays out the glyphs of a constructed script and renders them
+// to SVG. Glyph shapes are SVG path data attached to each Letter with
+// alphabet.WithGlyph, drawn in an em square of Layout.EmSize units with the
+// origin at its top left corner.
+package glyph
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Direction is the direction glyphs advance in.
+type Direction int
+
+const (
+	// LeftToRight writes glyphs rightwards and lines downwards.
+	LeftToRight Direction = iota
+	// RightToLeft writes glyphs leftwards and lines downwards.
+	RightToLeft
+	// TopToBottom writes glyphs downwards and columns rightwards.
+	TopToBottom
+)
+
+// Ligature draws a sequence of Letters as a single glyph.
+type Ligature struct {
+	// Spelling is the sequence of Letters, written as a string.
+	Spelling string
+	// Path is SVG path data for the ligature.
+	Path string
+	// Advance is the width of the ligature, or its height in TopToBottom
+	// text. Zero means one em per Letter replaced.
+	Advance float64
+}
+
+// Layout configures how text is composed.
+type Layout struct {
+	Direction Direction
+	// EmSize is the size of the em square glyph paths are drawn in. Zero
+	// means 100.
+	EmSize float64
+	// Advances overrides the advance of Letters, keyed by their lower form.
+	// Letters not listed advance by one em.
+	Advances map[string]float64
+	// Spacing is added between consecutive glyphs.
+	Spacing float64
+	// WordSpace is the advance of a space. Zero means half an em.
+	WordSpace float64
+	// LineGap is added between lines, or columns in TopToBottom text.
+	LineGap float64
+	// Ligatures are tried before single Letters, longest spelling first.
+	Ligatures []Ligature
+}
+
+func (l Layout) emSize() float64 {
+	if l.EmSize == 0 {
+		return 100
+	}
+	return l.EmSize
+}
+
+func (l Layout) wordSpace() float64 {
+	if l.WordSpace == 0 {
+		return l.emSize() / 2
+	}
+	return l.WordSpace
+}
+
+// Placed is a glyph positioned by Compose. X and Y give the top left corner of
+// its em square.
+type Placed struct {
+	X, Y float64
+	Path string
+	// Text is the part of the input the glyph stands for.
+	Text string
+}
+
+// Error is returned by Compose for text it cannot draw.
+type Error struct {
+	Input  string
+	Offset int
+	Text   string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("glyph: no glyph for %q at byte offset %d of %q", e.Text, e.Offset, e.Input)
+}
+
+type compiledLigature struct {
+	Ligature
+	letters []alphabet.Letter
+}
+
+// Compose positions the glyphs of text written in the Alphabet. Spaces advance
+// by the word space and newlines start a new line. It returns the glyphs along
+// with the width and height of the composed text.
+func Compose(a alphabet.Alphabet, text string, layout Layout) (placed []Placed, width, height float64, err error) {
+	em := layout.emSize()
+
+	ligatures := make([]compiledLigature, 0, len(layout.Ligatures))
+	for _, ligature := range layout.Ligatures {
+		letters, err := a.Tokenize(strings.ToLower(ligature.Spelling))
+		var ambiguous *alphabet.AmbiguousError
+		if err != nil && !errors.As(err, &ambiguous) {
+			return nil, 0, 0, fmt.Errorf("glyph: ligature %q: %w", ligature.Spelling, err)
+		}
+		ligatures = append(ligatures, compiledLigature{ligature, letters})
+	}
+	slices.SortStableFunc(ligatures, func(x, y compiledLigature) int { return len(y.letters) - len(x.letters) })
+
+	// pen is the distance along the current line and line the index of it
+	pen, line, longest := 0.0, 0, 0.0
+	advances := []float64{}
+	advance := func(distance float64) {
+		pen += distance
+		longest = max(longest, pen)
+	}
+	place := func(path, text string, distance float64) {
+		if pen > 0 {
+			advance(layout.Spacing)
+		}
+		offset := float64(line) * (em + layout.LineGap)
+		switch layout.Direction {
+		case TopToBottom:
+			placed = append(placed, Placed{X: offset, Y: pen, Path: path, Text: text})
+		default:
+			placed = append(placed, Placed{X: pen, Y: offset, Path: path, Text: text})
+		}
+		advances = append(advances, distance)
+		advance(distance)
+	}
+
+	segments := a.Segment(text)
+	for i := 0; i < len(segments); {
+		segment := segments[i]
+		if segment.Letter == nil {
+			switch segment.Text {
+			case " ":
+				advance(layout.wordSpace())
+			case "\n":
+				pen = 0
+				line++
+			default:
+				return nil, 0, 0, &Error{Input: text, Offset: segment.Offset, Text: segment.Text}
+			}
+			i++
+			continue
+		}
+
+		if ligature, ok := matchLigature(ligatures, segments, i); ok {
+			distance := ligature.Advance
+			if distance == 0 {
+				distance = em * float64(len(ligature.letters))
+			}
+			end := i + len(ligature.letters) - 1
+			place(ligature.Path, text[segment.Offset:segments[end].Offset+len(segments[end].Text)], distance)
+			i += len(ligature.letters)
+			continue
+		}
+
+		if segment.Letter.Glyph() == "" {
+			return nil, 0, 0, &Error{Input: text, Offset: segment.Offset, Text: segment.Text}
+		}
+		distance, ok := layout.Advances[segment.Letter.Lower()]
+		if !ok {
+			distance = em
+		}
+		place(segment.Letter.Glyph(), segment.Text, distance)
+		i++
+	}
+
+	across := float64(line+1)*em + float64(line)*layout.LineGap
+	switch layout.Direction {
+	case TopToBottom:
+		width, height = across, longest
+	case RightToLeft:
+		// mirror positions so that lines start at the right edge
+		for j := range placed {
+			placed[j].X = longest - placed[j].X - advances[j]
+		}
+		width, height = longest, across
+	default:
+		width, height = longest, across
+	}
+	return placed, width, height, nil
+}
+
+func matchLigature(ligatures []compiledLigature, segments []alphabet.Segment, i int) (compiledLigature, bool) {
+	for _, ligature := range ligatures {
+		if len(ligature.letters) == 0 || i+len(ligature.letters) > len(segments) {
+			continue
+		}
+		matched := true
+		for j, letter := range ligature.letters {
+			if segments[i+j].Letter == nil || segments[i+j].Letter.Lower() != letter.Lower() {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return ligature, true
+		}
+	}
+	return compiledLigature{}, false
+}
@@ -0,0 +1,100 @@
+package glyph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+func testAlphabet() alphabet.Alphabet {
+	return alphabet.New([]alphabet.Letter{
+		alphabet.WithGlyph(alphabet.NewLetter("A", "a"), "M 50 10 L 90 90 H 10 Z"),
+		alphabet.WithGlyph(alphabet.NewLetter("K", "k"), "M 10 10 V 90 M 90 10 L 10 50 L 90 90"),
+		alphabet.WithGlyph(alphabet.NewLetter("I", "i"), "M 50 10 V 90"),
+		alphabet.NewLetter("O", "o"),
+	})
+}
+
+func TestCompose(t *testing.T) {
+	a := testAlphabet()
+	layout := Layout{
+		Advances:  map[string]float64{"i": 40},
+		Ligatures: []Ligature{{Spelling: "ka", Path: "M 0 0 H 150", Advance: 150}},
+	}
+
+	for _, testCase := range []struct {
+		Direction Direction
+		Text      string
+		X, Y      []float64
+		Width     float64
+		Height    float64
+	}{
+		{LeftToRight, "kai ik", []float64{0, 150, 240, 280}, []float64{0, 0, 0, 0}, 380, 100},
+		{RightToLeft, "kai ik", []float64{230, 190, 100, 0}, []float64{0, 0, 0, 0}, 380, 100},
+		{TopToBottom, "ai\nk", []float64{0, 0, 100}, []float64{0, 100, 0}, 200, 140},
+	} {
+		layout.Direction = testCase.Direction
+		placed, width, height, err := Compose(a, testCase.Text, layout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(placed) != len(testCase.X) {
+			t.Logf("Expected %d glyphs; got %d\n", len(testCase.X), len(placed))
+			t.Fail()
+			continue
+		}
+		for i, glyph := range placed {
+			if glyph.X != testCase.X[i] || glyph.Y != testCase.Y[i] {
+				t.Logf("Direction %d: expected glyph %d (%q) at (%g, %g); got (%g, %g)\n",
+					testCase.Direction, i, glyph.Text, testCase.X[i], testCase.Y[i], glyph.X, glyph.Y)
+				t.Fail()
+			}
+		}
+		if width != testCase.Width || height != testCase.Height {
+			t.Logf("Direction %d: expected size %gx%g; got %gx%g\n",
+				testCase.Direction, testCase.Width, testCase.Height, width, height)
+			t.Fail()
+		}
+	}
+
+	if _, _, _, err := Compose(a, "ko", layout); err == nil {
+		t.Log("Expected a letter without a glyph to fail")
+		t.Fail()
+	}
+}
+
+func TestSVG(t *testing.T) {
+	a := testAlphabet()
+
+	var svg strings.Builder
+	if err := RenderSVG(&svg, a, "kia", Layout{}, Style{StrokeWidth: 8}); err != nil {
+		t.Fatal(err)
+	}
+	if count := strings.Count(svg.String(), "<path"); count != 3 {
+		t.Logf("Expected 3 paths; got %d in:\n%s", count, svg.String())
+		t.Fail()
+	}
+	if !strings.Contains(svg.String(), `width="144" height="48"`) || !strings.Contains(svg.String(), `stroke-width="8"`) {
+		t.Logf("Expected a stroked 144x48 image; got:\n%s", svg.String())
+		t.Fail()
+	}
+
+	var chart strings.Builder
+	if err := WriteChart(&chart, a, 2, Layout{}, Style{}); err != nil {
+		t.Fatal(err)
+	}
+	if count := strings.Count(chart.String(), "<text"); count != 3 {
+		t.Logf("Expected 3 labelled letters in the chart; got %d in:\n%s", count, chart.String())
+		t.Fail()
+	}
+
+	var empty strings.Builder
+	if err := WriteChart(&empty, alphabet.New([]alphabet.Letter{alphabet.NewLetter("A", "a")}), 2, Layout{}, Style{}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(empty.String(), `width="0" height="0"`) {
+		t.Logf("Expected an empty chart to have no size; got:\n%s", empty.String())
+		t.Fail()
+	}
+}
@@ -0,0 +1,116 @@
+package glyph
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Style configures how glyphs are drawn to SVG.
+type Style struct {
+	// Size is the rendered size of an em in pixels. Zero means 48.
+	Size float64
+	// StrokeWidth, when above zero, strokes paths with lines of this width in
+	// glyph units instead of filling them.
+	StrokeWidth float64
+	// Color is the fill or stroke color. Empty means black.
+	Color string
+	// Margin is the space left around the text, in glyph units.
+	Margin float64
+}
+
+func (s Style) size() float64 {
+	if s.Size == 0 {
+		return 48
+	}
+	return s.Size
+}
+
+func (s Style) paint() string {
+	color := s.Color
+	if color == "" {
+		color = "black"
+	}
+	if s.StrokeWidth > 0 {
+		return fmt.Sprintf(`fill="none" stroke="%s" stroke-width="%s" stroke-linecap="round" stroke-linejoin="round"`,
+			html.EscapeString(color), number(s.StrokeWidth))
+	}
+	return fmt.Sprintf(`fill="%s"`, html.EscapeString(color))
+}
+
+// RenderSVG composes text written in the Alphabet and writes it to w as an SVG
+// document.
+func RenderSVG(w io.Writer, a alphabet.Alphabet, text string, layout Layout, style Style) error {
+	placed, width, height, err := Compose(a, text, layout)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+	writeHeader(out, width, height, layout, style)
+	for _, glyph := range placed {
+		writeGlyph(out, glyph)
+	}
+	fmt.Fprintln(out, "</g>\n</svg>")
+	return out.Flush()
+}
+
+// WriteChart writes an SVG chart of every Letter in the Alphabet that has a
+// glyph, in alphabet order, with the lower form of each Letter beneath its
+// glyph. If no Letter has a glyph, the chart is empty and has no size.
+func WriteChart(w io.Writer, a alphabet.Alphabet, columns int, layout Layout, style Style) error {
+	columns = max(columns, 1)
+	em := layout.emSize()
+	cell := em * 1.5
+
+	var placed []Placed
+	for _, letter := range a.GetLetters().ToSlice() {
+		if letter.Glyph() == "" {
+			continue
+		}
+		i := len(placed)
+		placed = append(placed, Placed{
+			X:    float64(i%columns) * cell,
+			Y:    float64(i/columns) * (cell + em/2),
+			Path: letter.Glyph(),
+			Text: letter.Lower(),
+		})
+	}
+	var width, height float64
+	if len(placed) > 0 {
+		rows := (len(placed) + columns - 1) / columns
+		width = float64(min(columns, len(placed)))*cell - (cell - em)
+		height = float64(rows)*(cell+em/2) - (cell - em)
+	}
+
+	out := bufio.NewWriter(w)
+	writeHeader(out, width, height, layout, style)
+	for _, glyph := range placed {
+		writeGlyph(out, glyph)
+		fmt.Fprintf(out, `<text x="%s" y="%s" font-size="%s" text-anchor="middle" fill="gray" stroke="none">%s</text>`+"\n",
+			number(glyph.X+em/2), number(glyph.Y+em*1.4), number(em/3), html.EscapeString(glyph.Text))
+	}
+	fmt.Fprintln(out, "</g>\n</svg>")
+	return out.Flush()
+}
+
+func writeHeader(out *bufio.Writer, width, height float64, layout Layout, style Style) {
+	scale := style.size() / layout.emSize()
+	margin := style.Margin
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="%s %s %s %s">`+"\n",
+		number((width+2*margin)*scale), number((height+2*margin)*scale),
+		number(-margin), number(-margin), number(width+2*margin), number(height+2*margin))
+	fmt.Fprintf(out, "<g %s>\n", style.paint())
+}
+
+func writeGlyph(out *bufio.Writer, glyph Placed) {
+	fmt.Fprintf(out, `<path transform="translate(%s %s)" d="%s"><title>%s</title></path>`+"\n",
+		number(glyph.X), number(glyph.Y), html.EscapeString(glyph.Path), html.EscapeString(glyph.Text))
+}
+
+// number formats a coordinate without trailing zeros.
+func number(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
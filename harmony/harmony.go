@@ -0,0 +1,163 @@
+// Package harmony implements vowel and consonant harmony: affixes take the
+// variant of their harmonizing segments that agrees with the stem they attach
+// to.
+package harmony
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/morph"
+)
+
+// Value is one side of a harmony set, such as front or back vowels.
+type Value struct {
+	Name string
+	// Class is a class expression matching the Letters with this Value, such
+	// as "front" or "[+syllabic -back]".
+	Class string
+	// Features, if set, turn a Letter into its counterpart with this Value
+	// using Alphabet.Modify. Otherwise a Letter becomes the Letter at the same
+	// position in Class as it has in the Class of its own Value.
+	Features alphabet.Features
+}
+
+// System is a harmony set over an Alphabet.
+type System struct {
+	alphabet    alphabet.Alphabet
+	values      []Value
+	classes     []alphabet.ClassExpr
+	transparent *alphabet.ClassExpr
+}
+
+// New makes a System with the given Values. Letters matching the transparent
+// class expression, if it is not empty, neither decide nor undergo harmony,
+// such as neutral vowels.
+func New(a alphabet.Alphabet, transparent string, values ...Value) (*System, error) {
+	if len(values) < 2 {
+		return nil, fmt.Errorf("harmony: %d values, need at least 2", len(values))
+	}
+	s := &System{alphabet: a, values: values}
+	for _, value := range values {
+		class, err := alphabet.ParseClassExpr(value.Class)
+		if err != nil {
+			return nil, fmt.Errorf("harmony: value %q: %w", value.Name, err)
+		}
+		s.classes = append(s.classes, class)
+	}
+	if transparent != "" {
+		class, err := alphabet.ParseClassExpr(transparent)
+		if err != nil {
+			return nil, fmt.Errorf("harmony: transparent: %w", err)
+		}
+		s.transparent = &class
+	}
+	return s, nil
+}
+
+// Values returns the Values of the System.
+func (s *System) Values() []Value { return s.values }
+
+// valueOf returns the index of the Value of a Letter, or -1 if it does not
+// harmonize.
+func (s *System) valueOf(l alphabet.Letter) int {
+	if l == nil || (s.transparent != nil && s.transparent.Matches(s.alphabet, l)) {
+		return -1
+	}
+	for i, class := range s.classes {
+		if class.Matches(s.alphabet, l) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Value returns the name of the Value of a stem: that of its last harmonizing
+// Letter, or of its first if fromStart is true, as for prefixes. It returns
+// false if no Letter of the stem harmonizes.
+func (s *System) Value(stem string, fromStart bool) (string, bool) {
+	segments := s.alphabet.Segment(stem)
+	for i := range segments {
+		j := len(segments) - 1 - i
+		if fromStart {
+			j = i
+		}
+		if v := s.valueOf(segments[j].Letter); v >= 0 {
+			return s.values[v].Name, true
+		}
+	}
+	return "", false
+}
+
+// Harmonize rewrites every harmonizing Letter of an affix to its counterpart
+// with the named Value. Letters with no counterpart are kept.
+func (s *System) Harmonize(affix, value string) (string, error) {
+	target := -1
+	for i, v := range s.values {
+		if v.Name == value {
+			target = i
+		}
+	}
+	if target < 0 {
+		return "", fmt.Errorf("harmony: unknown value %q", value)
+	}
+
+	var b strings.Builder
+	for _, segment := range s.alphabet.Segment(affix) {
+		from := s.valueOf(segment.Letter)
+		if from < 0 || from == target {
+			b.WriteString(segment.Text)
+			continue
+		}
+		l := s.counterpart(segment.Letter, from, target)
+		if segment.IsUpper() {
+			b.WriteString(l.Upper())
+		} else {
+			b.WriteString(l.Lower())
+		}
+	}
+	return b.String(), nil
+}
+
+// counterpart returns the Letter with the target Value that corresponds to a
+// Letter with another Value.
+func (s *System) counterpart(l alphabet.Letter, from, to int) alphabet.Letter {
+	if features := s.values[to].Features; len(features) > 0 {
+		if modified, ok := s.alphabet.Modify(l, features); ok {
+			return modified
+		}
+		return l
+	}
+	source, err := s.alphabet.Query(s.values[from].Class)
+	if err != nil {
+		return l
+	}
+	destination, err := s.alphabet.Query(s.values[to].Class)
+	if err != nil {
+		return l
+	}
+	for i, letter := range source.ToSlice() {
+		if letter.Lower() == l.Lower() && i < destination.Len() {
+			return destination.ToSlice()[i]
+		}
+	}
+	return l
+}
+
+// Rule returns a morph.FormationRule that harmonizes a bound Morpheme with
+// the Morpheme it attaches to. Suffixes agree with the end of their base and
+// prefixes with its start. Give it to morph.NewSuffix or morph.NewPrefix.
+func (s *System) Rule() morph.FormationRule {
+	return func(attached, base morph.Morpheme) string {
+		value, ok := s.Value(base.String(), attached.IsPrefix())
+		if !ok {
+			return attached.String()
+		}
+		harmonized, err := s.Harmonize(attached.String(), value)
+		if err != nil {
+			return attached.String()
+		}
+		return harmonized
+	}
+}
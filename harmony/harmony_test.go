@@ -0,0 +1,144 @@
+package harmony
+
+import (
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/morph"
+)
+
+func vowelAlphabet() alphabet.Alphabet {
+	return alphabet.New([]alphabet.Letter{
+		alphabet.NewLetter("A", "a", 'V', 'B'),
+		alphabet.NewLetter("E", "e", 'V', 'F'),
+		alphabet.NewLetter("I", "i", 'V', 'N'),
+		alphabet.NewLetter("K", "k", 'C'),
+		alphabet.NewLetter("L", "l", 'C'),
+		alphabet.NewLetter("M", "m", 'C'),
+		alphabet.NewLetter("O", "o", 'V', 'B'),
+		alphabet.NewLetter("R", "r", 'C'),
+		alphabet.NewLetter("T", "t", 'C'),
+		alphabet.NewLetter("Ö", "ö", 'V', 'F'),
+	}, alphabet.WithShorthand('F', "front"), alphabet.WithShorthand('B', "back"), alphabet.WithShorthand('N', "neutral"))
+}
+
+func TestHarmony(t *testing.T) {
+	s, err := New(vowelAlphabet(), "neutral",
+		Value{Name: "front", Class: "front"},
+		Value{Name: "back", Class: "back"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		Stem   string
+		Prefix bool
+		Value  string
+		OK     bool
+	}{
+		{"kot", false, "back", true},
+		{"köt", false, "front", true},
+		{"kötar", false, "back", true},
+		{"kötar", true, "front", true},
+		{"kotti", false, "back", true},
+		{"kit", false, "", false},
+	} {
+		value, ok := s.Value(testCase.Stem, testCase.Prefix)
+		if value != testCase.Value || ok != testCase.OK {
+			t.Logf("Expected the value of %q to be %q (%v); got %q (%v)\n", testCase.Stem, testCase.Value, testCase.OK, value, ok)
+			t.Fail()
+		}
+	}
+
+	for _, testCase := range []struct {
+		Affix  string
+		Value  string
+		Output string
+	}{
+		{"lar", "front", "ler"},
+		{"ler", "back", "lar"},
+		{"tömi", "back", "tomi"},
+		{"LAR", "front", "LER"},
+		{"lar", "back", "lar"},
+	} {
+		output, err := s.Harmonize(testCase.Affix, testCase.Value)
+		if err != nil || output != testCase.Output {
+			t.Logf("Expected %q to harmonize to %q as %s; got %q (%v)\n", testCase.Affix, testCase.Output, testCase.Value, output, err)
+			t.Fail()
+		}
+	}
+	if _, err := s.Harmonize("lar", "round"); err == nil {
+		t.Logf("Expected an error for an unknown value\n")
+		t.Fail()
+	}
+	if _, err := New(vowelAlphabet(), "", Value{Name: "front", Class: "front"}); err == nil {
+		t.Logf("Expected an error for a single value\n")
+		t.Fail()
+	}
+}
+
+func TestFeatureHarmony(t *testing.T) {
+	a := alphabet.New([]alphabet.Letter{
+		alphabet.WithIPA(alphabet.NewLetter("A", "a", 'V'), "a"),
+		alphabet.WithIPA(alphabet.NewLetter("S", "s", 'C'), "s"),
+		alphabet.WithIPA(alphabet.NewLetter("SH", "sh", 'C'), "ʃ"),
+		alphabet.WithIPA(alphabet.NewLetter("T", "t", 'C'), "t"),
+	})
+	s, err := New(a, "",
+		Value{Name: "anterior", Class: "[+strident +anterior]", Features: alphabet.Features{alphabet.Anterior: true, alphabet.Distributed: false}},
+		Value{Name: "posterior", Class: "[+strident -anterior]", Features: alphabet.Features{alphabet.Anterior: false, alphabet.Distributed: true}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, testCase := range []struct {
+		Affix  string
+		Value  string
+		Output string
+	}{
+		{"sa", "posterior", "sha"},
+		{"shat", "anterior", "sat"},
+		{"tat", "anterior", "tat"},
+	} {
+		output, err := s.Harmonize(testCase.Affix, testCase.Value)
+		if err != nil || output != testCase.Output {
+			t.Logf("Expected %q to harmonize to %q as %s; got %q (%v)\n", testCase.Affix, testCase.Output, testCase.Value, output, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestRule(t *testing.T) {
+	s, err := New(vowelAlphabet(), "neutral",
+		Value{Name: "front", Class: "front"},
+		Value{Name: "back", Class: "back"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plural := morph.NewSuffix("lar", s.Rule())
+	locative := morph.NewSuffix("ta", s.Rule())
+	negative := morph.NewPrefix("me", s.Rule())
+
+	for _, testCase := range []struct {
+		Input  []morph.Morpheme
+		Output string
+	}{
+		{[]morph.Morpheme{morph.NewStem("ev"), plural}, "evler"},
+		{[]morph.Morpheme{morph.NewStem("kot"), plural}, "kotlar"},
+		{[]morph.Morpheme{morph.NewStem("köt"), plural.Combine(locative)}, "kötlerte"},
+		{[]morph.Morpheme{plural, morph.NewStem("kit")}, "kitlar"},
+		{[]morph.Morpheme{negative, morph.NewStem("kor")}, "makor"},
+		{[]morph.Morpheme{morph.NewStem("kot"), morph.NewSuffix("ler")}, "kotler"},
+	} {
+		output := testCase.Input[0]
+		for _, m := range testCase.Input[1:] {
+			output = output.Combine(m)
+		}
+		if output.String() != testCase.Output {
+			t.Logf("Expected %v to combine to %q; got %q\n", testCase.Input, testCase.Output, output)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Package inventory generates plausible phoneme inventories for new languages,
+// weighting its choices by how common each sound is across the world's
+// languages.
+package inventory
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Phoneme is a sound that may be chosen for an inventory.
+type Phoneme struct {
+	IPA string
+	// Romanization spells the sound in Latin letters. It is a single rune for
+	// every built-in Phoneme, so that no spelling splits into others.
+	Romanization string
+	// Class is the manner of a consonant, such as "stop", or "vowel". It is
+	// one of the names in Manners.
+	Class alphabet.ClassName
+	// Frequency is the share of languages with the sound, from 0 to 1.
+	Frequency float64
+	// Requires lists the IPA of sounds an inventory must have before it may
+	// have this one, such as "k" for "g".
+	Requires []string
+}
+
+// Manners are the Classes that Generate puts Letters in by manner, along with
+// their names.
+var Manners = map[rune]alphabet.ClassName{
+	'P': "stop",
+	'N': "nasal",
+	'F': "fricative",
+	'A': "affricate",
+	'L': "liquid",
+	'G': "glide",
+	'V': "vowel",
+}
+
+// Consonants are the consonants an inventory is chosen from, with rough
+// frequencies across languages.
+var Consonants = []Phoneme{
+	{"p", "p", "stop", 0.86, nil},
+	{"b", "b", "stop", 0.63, []string{"p"}},
+	{"t", "t", "stop", 0.68, nil},
+	{"d", "d", "stop", 0.45, []string{"t"}},
+	{"k", "k", "stop", 0.90, nil},
+	{"g", "g", "stop", 0.56, []string{"k"}},
+	{"q", "q", "stop", 0.14, []string{"k"}},
+	{"ʔ", "'", "stop", 0.37, nil},
+	{"m", "m", "nasal", 0.96, nil},
+	{"n", "n", "nasal", 0.78, nil},
+	{"ɲ", "ñ", "nasal", 0.42, []string{"n"}},
+	{"ŋ", "ŋ", "nasal", 0.63, []string{"m", "n"}},
+	{"f", "f", "fricative", 0.43, nil},
+	{"v", "v", "fricative", 0.28, []string{"f"}},
+	{"θ", "þ", "fricative", 0.05, []string{"s"}},
+	{"ð", "ð", "fricative", 0.05, []string{"θ"}},
+	{"s", "s", "fricative", 0.66, nil},
+	{"z", "z", "fricative", 0.29, []string{"s"}},
+	{"ʃ", "š", "fricative", 0.37, []string{"s"}},
+	{"ʒ", "ž", "fricative", 0.16, []string{"ʃ"}},
+	{"x", "x", "fricative", 0.20, []string{"k"}},
+	{"ɣ", "ǧ", "fricative", 0.15, []string{"x"}},
+	{"h", "h", "fricative", 0.62, nil},
+	{"ts", "c", "affricate", 0.20, []string{"t", "s"}},
+	{"tʃ", "č", "affricate", 0.40, []string{"t"}},
+	{"dʒ", "j", "affricate", 0.27, []string{"tʃ"}},
+	{"l", "l", "liquid", 0.68, nil},
+	{"r", "ř", "liquid", 0.44, nil},
+	{"ɾ", "r", "liquid", 0.20, nil},
+	{"j", "y", "glide", 0.90, nil},
+	{"w", "w", "glide", 0.82, nil},
+}
+
+// core are the consonants every generated inventory has.
+var core = []string{"p", "t", "k", "m", "n"}
+
+// VowelSystem is a symmetric set of vowels.
+type VowelSystem struct {
+	Vowels []Phoneme
+	// Frequency is the share of languages with a system of this shape.
+	Frequency float64
+}
+
+func vowels(ipa ...string) []Phoneme {
+	romanizations := map[string]string{
+		"i": "i", "e": "e", "ɛ": "è", "a": "a", "ɔ": "ò", "o": "o", "u": "u",
+		"ə": "ë", "ɪ": "ï", "ʊ": "ü",
+	}
+	phonemes := make([]Phoneme, len(ipa))
+	for i, symbol := range ipa {
+		phonemes[i] = Phoneme{IPA: symbol, Romanization: romanizations[symbol], Class: "vowel", Frequency: 1}
+	}
+	return phonemes
+}
+
+// VowelSystems are the vowel systems an inventory is chosen from, each
+// balanced between front and back vowels.
+var VowelSystems = []VowelSystem{
+	{vowels("i", "a", "u"), 0.10},
+	{vowels("i", "e", "a", "o", "u"), 0.45},
+	{vowels("i", "e", "a", "o", "u", "ə"), 0.15},
+	{vowels("i", "e", "ɛ", "a", "ɔ", "o", "u"), 0.25},
+	{vowels("i", "ɪ", "e", "ɛ", "a", "ɔ", "o", "ʊ", "u"), 0.05},
+}
+
+// Options configure Generate.
+type Options struct {
+	// Seed makes the inventory reproducible.
+	Seed int64
+	// Consonants is the number of consonants. If zero, it is chosen between
+	// 15 and 28. Every inventory has /p t k m n/, so counts below 5 give 5
+	// consonants.
+	Consonants int
+	// Vowels is the number of vowels; the VowelSystem nearest in size is
+	// used. If zero, a VowelSystem is chosen by frequency.
+	Vowels int
+}
+
+// Generate returns an Alphabet of consonants in the Class 'C' and vowels in
+// the Class 'V', each also in the Class of its manner, named as in Manners.
+// Letters are spelled
+// with their romanization, have their IPA value and are weighted in their
+// Class by frequency. Consonants are drawn by frequency, beginning with
+// /p t k m n/, and only once the sounds they require have been drawn.
+func Generate(options Options) alphabet.Alphabet {
+	r := rand.New(rand.NewSource(options.Seed))
+
+	count := options.Consonants
+	if count <= 0 {
+		count = 15 + r.Intn(14)
+	}
+	chosen := map[string]bool{}
+	for _, ipa := range core {
+		chosen[ipa] = true
+	}
+	for len(chosen) < min(count, len(Consonants)) {
+		eligible := []Phoneme{}
+		total := 0.0
+		for _, c := range Consonants {
+			if !chosen[c.IPA] && allChosen(chosen, c.Requires) {
+				eligible = append(eligible, c)
+				total += c.Frequency
+			}
+		}
+		if len(eligible) == 0 {
+			break
+		}
+		pick := r.Float64() * total
+		for _, c := range eligible {
+			if pick -= c.Frequency; pick < 0 {
+				chosen[c.IPA] = true
+				break
+			}
+		}
+	}
+
+	letters := []alphabet.Letter{}
+	for _, c := range Consonants {
+		if chosen[c.IPA] {
+			letters = append(letters, newLetter(c, 'C'))
+		}
+	}
+	for _, v := range vowelSystem(r, options.Vowels).Vowels {
+		letters = append(letters, newLetter(v, 'V'))
+	}
+	names := []alphabet.Option{}
+	for shorthand, name := range Manners {
+		names = append(names, alphabet.WithShorthand(shorthand, name))
+	}
+	return alphabet.New(letters, names...)
+}
+
+func allChosen(chosen map[string]bool, ipa []string) bool {
+	for _, symbol := range ipa {
+		if !chosen[symbol] {
+			return false
+		}
+	}
+	return true
+}
+
+// vowelSystem returns the VowelSystem nearest in size to n, or one chosen by
+// frequency if n is zero.
+func vowelSystem(r *rand.Rand, n int) VowelSystem {
+	if n > 0 {
+		nearest := VowelSystems[0]
+		for _, system := range VowelSystems {
+			if math.Abs(float64(len(system.Vowels)-n)) < math.Abs(float64(len(nearest.Vowels)-n)) {
+				nearest = system
+			}
+		}
+		return nearest
+	}
+	total := 0.0
+	for _, system := range VowelSystems {
+		total += system.Frequency
+	}
+	pick := r.Float64() * total
+	for _, system := range VowelSystems {
+		if pick -= system.Frequency; pick < 0 {
+			return system
+		}
+	}
+	return VowelSystems[len(VowelSystems)-1]
+}
+
+func newLetter(p Phoneme, class alphabet.Class) alphabet.Letter {
+	l := alphabet.NewLetter(strings.ToUpper(p.Romanization), p.Romanization, class, manner(p.Class))
+	l = alphabet.WithIPA(l, p.IPA)
+	return alphabet.WithWeight(l, class, p.Frequency)
+}
+
+// manner returns the Class Manners gives the name.
+func manner(name alphabet.ClassName) alphabet.Class {
+	for shorthand, n := range Manners {
+		if n == name {
+			return alphabet.Class(shorthand)
+		}
+	}
+	return 0
+}
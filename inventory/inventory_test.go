@@ -0,0 +1,109 @@
+package inventory
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+func spell(a alphabet.Alphabet, class alphabet.Class) []string {
+	letters := []string{}
+	for _, l := range a.GetLettersByClass(class).ToSlice() {
+		letters = append(letters, l.Lower())
+	}
+	return letters
+}
+
+func TestGenerate(t *testing.T) {
+	if !slices.Equal(spell(Generate(Options{Seed: 7}), 'C'), spell(Generate(Options{Seed: 7}), 'C')) {
+		t.Logf("Expected the same seed to generate the same inventory\n")
+		t.Fail()
+	}
+
+	requires := map[string][]string{}
+	for _, c := range Consonants {
+		requires[c.IPA] = c.Requires
+	}
+	different := false
+	for seed := int64(0); seed < 50; seed++ {
+		a := Generate(Options{Seed: seed})
+		if !slices.Equal(spell(a, 'C'), spell(Generate(Options{Seed: 0}), 'C')) {
+			different = true
+		}
+
+		ipa := map[string]bool{}
+		romanizations := map[string]bool{}
+		for _, l := range a.GetLetters().ToSlice() {
+			if l.IPA() == "" || romanizations[l.Lower()] {
+				t.Logf("Expected %q to have an IPA value and a unique romanization\n", l.Lower())
+				t.Fail()
+			}
+			ipa[l.IPA()] = true
+			romanizations[l.Lower()] = true
+		}
+		for symbol := range ipa {
+			for _, required := range requires[symbol] {
+				if !ipa[required] {
+					t.Logf("Expected /%s/ with /%s/ in seed %d\n", required, symbol, seed)
+					t.Fail()
+				}
+			}
+		}
+		for _, symbol := range core {
+			if !ipa[symbol] {
+				t.Logf("Expected /%s/ in seed %d\n", symbol, seed)
+				t.Fail()
+			}
+		}
+		if diagnostics := a.Validate(); diagnostics != nil {
+			t.Logf("Expected seed %d to generate a valid alphabet; got %v\n", seed, diagnostics)
+			t.Fail()
+		}
+		if n := a.GetLettersByClass('C').Len(); n < 15 || n > 28 {
+			t.Logf("Expected 15 to 28 consonants in seed %d; got %d\n", seed, n)
+			t.Fail()
+		}
+	}
+	if !different {
+		t.Logf("Expected different seeds to generate different inventories\n")
+		t.Fail()
+	}
+}
+
+func TestOptions(t *testing.T) {
+	for _, testCase := range []struct {
+		Options    Options
+		Consonants int
+		Vowels     []string
+	}{
+		{Options{Consonants: 10, Vowels: 5}, 10, []string{"i", "e", "a", "o", "u"}},
+		{Options{Consonants: 3, Vowels: 3}, 5, []string{"i", "a", "u"}},
+		{Options{Consonants: 100, Vowels: 8}, len(Consonants), []string{"i", "e", "è", "a", "ò", "o", "u"}},
+		{Options{Consonants: 20, Vowels: 1}, 20, []string{"i", "a", "u"}},
+	} {
+		a := Generate(testCase.Options)
+		if n := a.GetLettersByClass('C').Len(); n != testCase.Consonants {
+			t.Logf("Expected %d consonants for %+v; got %d\n", testCase.Consonants, testCase.Options, n)
+			t.Fail()
+		}
+		if diagnostics := a.Validate(); diagnostics != nil {
+			t.Logf("Expected a valid alphabet for %+v; got %v\n", testCase.Options, diagnostics)
+			t.Fail()
+		}
+		if vowels := spell(a, 'V'); !slices.Equal(vowels, testCase.Vowels) {
+			t.Logf("Expected vowels %q for %+v; got %q\n", testCase.Vowels, testCase.Options, vowels)
+			t.Fail()
+		}
+	}
+
+	a := Generate(Options{Seed: 1, Consonants: 10})
+	if a.Weight(a.GetLettersByClass('C').ToSlice()[0], 'C') != 0.86 {
+		t.Logf("Expected consonants to be weighted by frequency\n")
+		t.Fail()
+	}
+	if stop, ok := alphabet.ClassNamed(a, "stop"); !ok || !a.GetLettersByClass(stop).ToSlice()[0].IsClass('C') {
+		t.Logf("Expected stops to be consonants\n")
+		t.Fail()
+	}
+}
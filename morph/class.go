@@ -2,3 +2,28 @@ package morph
 
 // Class represents a class of Lexemes
 type Class rune
+
+// ClassNames gives Classes longer names, such as "noun" for 'n'.
+type ClassNames map[Class]string
+
+// Name returns the name of the Class, or its rune if it has none.
+func (n ClassNames) Name(c Class) string {
+	if name, ok := n[c]; ok {
+		return name
+	}
+	return string(rune(c))
+}
+
+// Class returns the Class with the name. It returns false if no Class has the
+// name and the name is longer than one rune.
+func (n ClassNames) Class(name string) (Class, bool) {
+	for class, n := range n {
+		if n == name {
+			return class, true
+		}
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		return Class(runes[0]), true
+	}
+	return 0, false
+}
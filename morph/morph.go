@@ -1,6 +1,9 @@
 package morph
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 // Morphemes represent a broad category of morphological elements, ranging from
 // affixes to word roots. Morphemes may be classified as "free" or "bound". A
@@ -22,11 +25,17 @@ type Morpheme interface {
 	// and a.IsPrefix() == b.IsPrefix(), then the receiver morpheme will be ordered
 	// first in the output.
 	Combine(Morpheme) Morpheme
+	// FormationRules returns the rules that decide the surface form of a bound
+	// Morpheme when it is attached to a free one. Free Morphemes have none.
+	// This method was added after the others, so types outside this package
+	// that implement Morpheme must add it; returning nil keeps their forms
+	// unchanged.
+	FormationRules() []FormationRule
 }
 
-// NewPrefix makes a new prefix Morpheme
-func NewPrefix(s string) Morpheme {
-	return boundMorpheme{s, true}
+// NewPrefix makes a new prefix Morpheme, optionally with FormationRules
+func NewPrefix(s string, rules ...FormationRule) Morpheme {
+	return newBoundMorpheme(s, true, rules)
 }
 
 // NewStem makes a new stem Morpheme
@@ -34,27 +43,45 @@ func NewStem(s string) Morpheme {
 	return freeMorpheme(s)
 }
 
-// NewSuffix makes a new suffix Morpheme
-func NewSuffix(s string) Morpheme {
-	return boundMorpheme{s, false}
+// NewSuffix makes a new suffix Morpheme, optionally with FormationRules
+func NewSuffix(s string, rules ...FormationRule) Morpheme {
+	return newBoundMorpheme(s, false, rules)
 }
 
-// NewMorpheme makes a new Morpheme.
-func NewMorpheme(s string, free, prefix bool) Morpheme {
+// NewMorpheme makes a new Morpheme. FormationRules only apply to bound
+// Morphemes.
+func NewMorpheme(s string, free, prefix bool, rules ...FormationRule) Morpheme {
 	if free {
 		return freeMorpheme(s)
 	}
-	return boundMorpheme{s, prefix}
+	return newBoundMorpheme(s, prefix, rules)
 }
 
+// boundMorpheme keeps its rules behind a pointer so that it stays comparable.
+// Bound Morphemes without rules are equal if their strings are.
 type boundMorpheme struct {
 	morpheme string
 	prefix   bool
+	rules    *[]FormationRule
+}
+
+func newBoundMorpheme(s string, prefix bool, rules []FormationRule) boundMorpheme {
+	b := boundMorpheme{morpheme: s, prefix: prefix}
+	if len(rules) > 0 {
+		b.rules = &rules
+	}
+	return b
 }
 
 func (b boundMorpheme) IsFree() bool   { return false }
 func (b boundMorpheme) IsPrefix() bool { return b.prefix }
 func (b boundMorpheme) String() string { return b.morpheme }
+func (b boundMorpheme) FormationRules() []FormationRule {
+	if b.rules == nil {
+		return nil
+	}
+	return *b.rules
+}
 func (b boundMorpheme) Combine(other Morpheme) Morpheme {
 	if other.IsFree() {
 		/*
@@ -68,9 +95,9 @@ func (b boundMorpheme) Combine(other Morpheme) Morpheme {
 		 replaced by "ly".]
 		*/
 		if b.IsPrefix() {
-			return NewMorpheme(b.String()+other.String(), true, true)
+			return NewMorpheme(surface(b, other)+other.String(), true, true)
 		}
-		return NewMorpheme(other.String()+b.String(), true, true)
+		return NewMorpheme(other.String()+surface(b, other), true, true)
 	} else if b.IsPrefix() != other.IsPrefix() {
 		/*
 		 If two bound morphemes are prefix + suffix, we combine them in that
@@ -99,15 +126,20 @@ func (b boundMorpheme) Combine(other Morpheme) Morpheme {
 	 must be handled at a higher level than this. given this example, consider
 	 that the only instances of words beginning with "reun-" regard "re-union"
 	 or "re-unite". in these words, "un-" is not the "un-" you see in "undo".]
+
+	 The rules of both morphemes are kept, to be applied to the whole when it
+	 is attached to a free morpheme.
 	*/
-	return NewMorpheme(b.String()+other.String(), false, b.IsPrefix())
+	rules := slices.Concat(b.FormationRules(), other.FormationRules())
+	return NewMorpheme(b.String()+other.String(), false, b.IsPrefix(), rules...)
 }
 
 type freeMorpheme string
 
-func (f freeMorpheme) IsFree() bool   { return true }
-func (f freeMorpheme) IsPrefix() bool { return false }
-func (f freeMorpheme) String() string { return string(f) }
+func (f freeMorpheme) IsFree() bool                    { return true }
+func (f freeMorpheme) IsPrefix() bool                  { return false }
+func (f freeMorpheme) String() string                  { return string(f) }
+func (f freeMorpheme) FormationRules() []FormationRule { return nil }
 func (f freeMorpheme) Combine(other Morpheme) Morpheme {
 	// example: ("dog", "house") => "doghouse"
 	if other.IsFree() {
@@ -115,8 +147,8 @@ func (f freeMorpheme) Combine(other Morpheme) Morpheme {
 	}
 	// example: ("do", "re-") => "redo"
 	if other.IsPrefix() {
-		return NewMorpheme(other.String()+f.String(), true, true)
+		return NewMorpheme(surface(other, f)+f.String(), true, true)
 	}
 	// example: ("do", "-ing") => "doing"
-	return NewMorpheme(f.String()+other.String(), true, true)
+	return NewMorpheme(f.String()+surface(other, f), true, true)
 }
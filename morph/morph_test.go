@@ -30,3 +30,43 @@ func TestMorpheme(t *testing.T) {
 		}
 	}
 }
+
+func TestComparable(t *testing.T) {
+	if NewSuffix("ly") != NewSuffix("ly") || NewPrefix("un").Combine(NewPrefix("re")) != NewPrefix("unre") {
+		t.Log("Expected Morphemes without rules to be equal if their strings are")
+		t.Fail()
+	}
+	keep := func(attached, base Morpheme) string { return attached.String() }
+	ly := NewSuffix("ly", keep)
+	if copied := ly; copied != ly || ly == NewSuffix("ly") {
+		t.Log("Expected a Morpheme with rules to only equal itself")
+		t.Fail()
+	}
+	if rules := ly.Combine(NewSuffix("ness", keep)).FormationRules(); len(rules) != 2 {
+		t.Logf("Expected combined Morphemes to keep both rules; got %d\n", len(rules))
+		t.Fail()
+	}
+}
+
+func TestClassNames(t *testing.T) {
+	names := ClassNames{'n': "noun", 'v': "verb"}
+	for _, testCase := range []struct {
+		Name  string
+		Class Class
+		OK    bool
+	}{
+		{"noun", 'n', true},
+		{"verb", 'v', true},
+		{"a", 'a', true},
+		{"adjective", 0, false},
+	} {
+		if class, ok := names.Class(testCase.Name); class != testCase.Class || ok != testCase.OK {
+			t.Logf("Expected %q to name %q (%t); got %q (%t)\n", testCase.Name, testCase.Class, testCase.OK, class, ok)
+			t.Fail()
+		}
+	}
+	if names.Name('n') != "noun" || names.Name('a') != "a" {
+		t.Logf("Expected 'n' to be named \"noun\" and 'a' \"a\"; got %q and %q\n", names.Name('n'), names.Name('a'))
+		t.Fail()
+	}
+}
@@ -3,5 +3,20 @@ package morph
 // InflectionRule represents a rule that embeds inflectional information.
 type InflectionRule func()
 
-// FormationRule represents a rule that governs lexeme combinations.
-type FormationRule func()
+// FormationRule represents a rule that governs lexeme combinations. It returns
+// the surface form of a bound Morpheme when attached to a base, such as the
+// variant of a suffix that agrees with the vowels of a stem.
+//
+// FormationRule used to take no arguments and return nothing; rules written as
+// a func() must now take the attached and base Morphemes and return a form.
+type FormationRule func(attached, base Morpheme) string
+
+// surface returns the form of a Morpheme after applying its FormationRules to
+// it, in order, when attached to the base.
+func surface(attached, base Morpheme) string {
+	s := attached.String()
+	for _, rule := range attached.FormationRules() {
+		s = rule(NewMorpheme(s, attached.IsFree(), attached.IsPrefix()), base)
+	}
+	return s
+}
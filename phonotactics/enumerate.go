@@ -0,0 +1,77 @@
+package phonotactics
+
+import (
+	"slices"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Syllables returns every syllable the Constraints allow, spelled in lower case
+// and sorted in the Alphabet's order. Banned sequences that only apply at the
+// edge of a word are ignored, since a syllable may occur anywhere in one.
+func (p *Phonotactics) Syllables() []string {
+	seen := map[string]bool{}
+	syllables := []string{}
+	for _, template := range p.templates {
+		p.fill(template, 0, []alphabet.Letter{}, -1, func(letters []alphabet.Letter, nucleus int) {
+			spelling := ""
+			for _, letter := range letters {
+				spelling += letter.Lower()
+			}
+			if !seen[spelling] && p.allows(letters, nucleus) {
+				seen[spelling] = true
+				syllables = append(syllables, spelling)
+			}
+		})
+	}
+	slices.SortFunc(syllables, p.alphabet.Compare)
+	return syllables
+}
+
+// fill calls emit with every sequence of Letters the Template can hold from the
+// given slot on, along with the index of the nucleus among them.
+func (p *Phonotactics) fill(template compiledTemplate, slot int, letters []alphabet.Letter, nucleus int, emit func([]alphabet.Letter, int)) {
+	if slot == len(template.Template) {
+		emit(letters, nucleus)
+		return
+	}
+	if template.Template[slot].Optional {
+		p.fill(template, slot+1, letters, nucleus, emit)
+	}
+	if slot == template.nucleus {
+		nucleus = len(letters)
+	}
+	for _, letter := range p.alphabet.GetLettersByClass(template.Template[slot].Class).ToSlice() {
+		p.fill(template, slot+1, append(letters[:len(letters):len(letters)], letter), nucleus, emit)
+	}
+}
+
+// allows checks a single syllable against clusters, Banned sequences and the
+// syllable positions of its Letters.
+func (p *Phonotactics) allows(letters []alphabet.Letter, nucleus int) bool {
+	if p.allowed(letters[:nucleus], letters[nucleus+1:]) != 0 {
+		return false
+	}
+	for i, letter := range letters {
+		role := alphabet.Coda
+		if i < nucleus {
+			role = alphabet.Onset
+		} else if i == nucleus {
+			role = alphabet.Nucleus
+		}
+		if !letter.Positions().Allows(role) {
+			return false
+		}
+	}
+	for _, banned := range p.banned {
+		if banned.initial || banned.final {
+			continue
+		}
+		for i := 0; i+len(banned.letters) <= len(letters); i++ {
+			if key(letters[i:i+len(banned.letters)]) == key(banned.letters) {
+				return false
+			}
+		}
+	}
+	return true
+}
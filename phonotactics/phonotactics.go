@@ -0,0 +1,398 @@
+// Package phonotactics checks words against the syllable structure of a
+// language, built on the Classes of an Alphabet, and lists the syllables it
+// allows.
+package phonotactics
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Constraints describe the words a language allows. Clusters and sequences are
+// spelled in Letters of the Alphabet.
+type Constraints struct {
+	// Templates are the allowed syllable shapes, such as "(C)(L)V(N)". See
+	// ParseTemplate for the syntax.
+	Templates []string
+	// Onsets lists the clusters of two or more Letters allowed before a
+	// nucleus, such as "pl". If empty, any cluster the Templates allow is.
+	Onsets []string
+	// Codas lists the clusters of two or more Letters allowed after a nucleus.
+	// If empty, any cluster the Templates allow is.
+	Codas []string
+	// Banned lists sequences of Letters that may not occur anywhere in a word,
+	// even across syllables. A leading or trailing "#" bans a sequence only at
+	// the start or end of a word.
+	Banned []string
+	// Nucleus is the Class of Letters that may be nuclei, besides those with
+	// the Syllabic feature. If zero, it is 'V'.
+	Nucleus alphabet.Class
+}
+
+// Phonotactics validates words against Constraints.
+type Phonotactics struct {
+	alphabet  alphabet.Alphabet
+	nucleus   alphabet.Class
+	templates []compiledTemplate
+	onsets    Clusters
+	codas     Clusters
+	banned    []sequence
+}
+
+type compiledTemplate struct {
+	Template
+	// nucleus is the index of the nucleus slot.
+	nucleus int
+}
+
+// sequence is a parsed Banned sequence.
+type sequence struct {
+	spelling       string
+	letters        []alphabet.Letter
+	initial, final bool
+}
+
+// New compiles Constraints for the given Alphabet. The nucleus of each Template
+// is its first Class that holds only Letters for which alphabet.IsNucleus is
+// true with the Nucleus Class, and it may not be optional.
+func New(a alphabet.Alphabet, constraints Constraints) (*Phonotactics, error) {
+	p := &Phonotactics{alphabet: a, nucleus: constraints.Nucleus}
+	if p.nucleus == 0 {
+		p.nucleus = 'V'
+	}
+	if len(constraints.Templates) == 0 {
+		return nil, errors.New("phonotactics: no templates")
+	}
+	for _, s := range constraints.Templates {
+		template, err := ParseTemplate(s)
+		if err != nil {
+			return nil, err
+		}
+		nucleus := slices.IndexFunc(template, func(slot Slot) bool { return p.isNucleusClass(slot.Class) })
+		if nucleus < 0 {
+			return nil, fmt.Errorf("phonotactics: template %q has no nucleus", s)
+		}
+		if template[nucleus].Optional {
+			return nil, fmt.Errorf("phonotactics: template %q has an optional nucleus", s)
+		}
+		p.templates = append(p.templates, compiledTemplate{template, nucleus})
+	}
+
+	var err error
+	if p.onsets, err = ParseClusters(a, constraints.Onsets); err != nil {
+		return nil, fmt.Errorf("phonotactics: onsets: %w", err)
+	}
+	if p.codas, err = ParseClusters(a, constraints.Codas); err != nil {
+		return nil, fmt.Errorf("phonotactics: codas: %w", err)
+	}
+	for _, s := range constraints.Banned {
+		banned := sequence{spelling: s}
+		spelling := s
+		spelling, banned.initial = strings.CutPrefix(spelling, string(alphabet.Boundary))
+		spelling, banned.final = strings.CutSuffix(spelling, string(alphabet.Boundary))
+		if banned.letters, err = Tokenize(a, spelling); err != nil {
+			return nil, fmt.Errorf("phonotactics: banned sequence %q: %w", s, err)
+		}
+		if len(banned.letters) == 0 {
+			return nil, fmt.Errorf("phonotactics: banned sequence %q has no letters", s)
+		}
+		p.banned = append(p.banned, banned)
+	}
+	return p, nil
+}
+
+// Clusters is a set of clusters of two or more Letters, such as the onsets a
+// language allows. A nil Clusters allows any cluster.
+type Clusters map[string]bool
+
+// ParseClusters tokenizes cluster spellings, such as "pl" or "str", with
+// Tokenize. It returns nil if there are none.
+func ParseClusters(a alphabet.Alphabet, spellings []string) (Clusters, error) {
+	if len(spellings) == 0 {
+		return nil, nil
+	}
+	clusters := Clusters{}
+	for _, s := range spellings {
+		letters, err := Tokenize(a, s)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %q: %w", s, err)
+		}
+		if len(letters) < 2 {
+			return nil, fmt.Errorf("%q is not a cluster", s)
+		}
+		clusters[key(letters)] = true
+	}
+	return clusters, nil
+}
+
+// Allows returns true if the Letters are a single Letter or none, or are one
+// of the Clusters.
+func (c Clusters) Allows(letters []alphabet.Letter) bool {
+	return len(letters) < 2 || c == nil || c[key(letters)]
+}
+
+// Tokenize splits a spelling into Letters of the Alphabet, ignoring case. It
+// accepts ambiguous spellings, since words are split by longest-match too.
+func Tokenize(a alphabet.Alphabet, s string) ([]alphabet.Letter, error) {
+	letters, err := a.Tokenize(strings.ToLower(s))
+	var ambiguous *alphabet.AmbiguousError
+	if err != nil && !errors.As(err, &ambiguous) {
+		return nil, err
+	}
+	return letters, nil
+}
+
+func (p *Phonotactics) isNucleusClass(c alphabet.Class) bool {
+	letters := p.alphabet.GetLettersByClass(c).ToSlice()
+	if len(letters) == 0 {
+		return false
+	}
+	for _, letter := range letters {
+		if !alphabet.IsNucleus(p.alphabet, letter, p.nucleus) {
+			return false
+		}
+	}
+	return true
+}
+
+// key identifies a sequence of Letters.
+func key(letters []alphabet.Letter) string {
+	forms := make([]string, len(letters))
+	for i, letter := range letters {
+		forms[i] = letter.Lower()
+	}
+	return strings.Join(forms, " ")
+}
+
+// allowed returns the Kind of Problem with the onset and coda of a syllable,
+// or zero if both are allowed.
+func (p *Phonotactics) allowed(onset, coda []alphabet.Letter) Kind {
+	if !p.onsets.Allows(onset) {
+		return IllegalOnset
+	}
+	if !p.codas.Allows(coda) {
+		return IllegalCoda
+	}
+	return 0
+}
+
+// Templates returns the syllable Templates, in the order they were given.
+func (p *Phonotactics) Templates() []Template {
+	templates := make([]Template, len(p.templates))
+	for i, template := range p.templates {
+		templates[i] = template.Template
+	}
+	return templates
+}
+
+// Kind classifies a Problem found by Validate.
+type Kind int
+
+const (
+	// Banned means the word contains a Banned sequence.
+	Banned Kind = iota + 1
+	// IllegalOnset means a syllable would need an onset cluster that is not
+	// allowed.
+	IllegalOnset
+	// IllegalCoda means a syllable would need a coda cluster that is not
+	// allowed.
+	IllegalCoda
+	// Unsyllabifiable means the rest of the word fits no Template.
+	Unsyllabifiable
+	// Misplaced means a Letter is in a position it may not occur in.
+	Misplaced
+)
+
+// Problem describes one reason a word is not allowed.
+type Problem struct {
+	Kind Kind
+	// Text and Offset locate the problem in the word.
+	Text   string
+	Offset int
+	// Rule is the Banned sequence of a Banned Problem.
+	Rule string
+	// Violation holds the details of a Misplaced Problem.
+	Violation alphabet.Violation
+}
+
+func (p Problem) String() string {
+	switch p.Kind {
+	case Banned:
+		return fmt.Sprintf("banned sequence %q at byte offset %d", p.Rule, p.Offset)
+	case IllegalOnset:
+		return fmt.Sprintf("onset %q at byte offset %d is not allowed", p.Text, p.Offset)
+	case IllegalCoda:
+		return fmt.Sprintf("coda %q at byte offset %d is not allowed", p.Text, p.Offset)
+	case Unsyllabifiable:
+		return fmt.Sprintf("%q at byte offset %d fits no syllable template", p.Text, p.Offset)
+	case Misplaced:
+		return p.Violation.String()
+	}
+	return "???"
+}
+
+// syllable is a span of Letters parsed by a Template.
+type syllable struct {
+	start, nucleus, end int
+}
+
+// Validate checks a word against the Constraints and returns every Problem
+// found, or none if the word is allowed. An error is returned if the word
+// cannot be tokenized.
+func (p *Phonotactics) Validate(word string) ([]Problem, error) {
+	letters, err := Tokenize(p.alphabet, word)
+	if err != nil {
+		return nil, err
+	}
+	segments := p.alphabet.Segment(word)
+	text := func(start, end int) (string, int) {
+		if start == end {
+			return "", len(word)
+		}
+		offset := segments[start].Offset
+		return word[offset : segments[end-1].Offset+len(segments[end-1].Text)], offset
+	}
+
+	problems := []Problem{}
+	for i := range letters {
+		for _, banned := range p.banned {
+			end := i + len(banned.letters)
+			if end > len(letters) || banned.initial && i > 0 || banned.final && end < len(letters) {
+				continue
+			}
+			if key(letters[i:end]) == key(banned.letters) {
+				problem := Problem{Kind: Banned, Rule: banned.spelling}
+				problem.Text, problem.Offset = text(i, end)
+				problems = append(problems, problem)
+			}
+		}
+	}
+
+	syllables, ok := p.parse(letters)
+	if !ok {
+		kind, start, end := p.failure(letters)
+		problem := Problem{Kind: kind}
+		problem.Text, problem.Offset = text(start, end)
+		return append(problems, problem), nil
+	}
+
+	roles := make([]alphabet.Position, len(letters))
+	for _, s := range syllables {
+		for i := s.start; i < s.end; i++ {
+			switch {
+			case i < s.nucleus:
+				roles[i] = alphabet.Onset
+			case i == s.nucleus:
+				roles[i] = alphabet.Nucleus
+			default:
+				roles[i] = alphabet.Coda
+			}
+		}
+	}
+	violations, err := alphabet.CheckPositions(p.alphabet, word, p.nucleus, roles)
+	if err != nil {
+		return nil, err
+	}
+	for _, violation := range violations {
+		problems = append(problems, Problem{
+			Kind:      Misplaced,
+			Text:      violation.Text,
+			Offset:    violation.Offset,
+			Violation: violation,
+		})
+	}
+	return problems, nil
+}
+
+// parse splits Letters into syllables, preferring the split with the fewest
+// misplaced Letters and then the one with the largest onsets.
+func (p *Phonotactics) parse(letters []alphabet.Letter) ([]syllable, bool) {
+	n := len(letters)
+	if n == 0 {
+		return nil, false
+	}
+	positions := alphabet.PositionsOf(p.alphabet, letters, p.nucleus)
+	misplaced := func(s syllable) (count int) {
+		for i := s.start; i < s.end; i++ {
+			role := alphabet.Coda
+			if i < s.nucleus {
+				role = alphabet.Onset
+			} else if i == s.nucleus {
+				role = alphabet.Nucleus
+			}
+			at := positions[i]&(alphabet.Initial|alphabet.Medial|alphabet.Final) | role
+			if !letters[i].Positions().Allows(at) {
+				count++
+			}
+		}
+		return
+	}
+
+	cost := make([]int, n+1)
+	next := make([]syllable, n+1)
+	for i := n - 1; i >= 0; i-- {
+		cost[i] = math.MaxInt
+		for _, template := range p.templates {
+			for _, m := range template.matches(p.alphabet, letters[i:], template.nucleus) {
+				s := syllable{start: i, nucleus: i + m.nucleus, end: i + m.length}
+				if cost[s.end] == math.MaxInt || p.allowed(letters[i:s.nucleus], letters[s.nucleus+1:s.end]) != 0 {
+					continue
+				}
+				c := cost[s.end] + misplaced(s)
+				if c < cost[i] || c == cost[i] && s.end < next[i].end {
+					cost[i], next[i] = c, s
+				}
+			}
+		}
+	}
+	if cost[0] == math.MaxInt {
+		return nil, false
+	}
+
+	syllables := []syllable{}
+	for i := 0; i < n; i = next[i].end {
+		syllables = append(syllables, next[i])
+	}
+	return syllables, true
+}
+
+// failure explains why Letters could not be parsed at the furthest point a
+// parse reached, returning the Kind of Problem and the Letters it concerns.
+func (p *Phonotactics) failure(letters []alphabet.Letter) (kind Kind, start, end int) {
+	reachable := make([]bool, len(letters)+1)
+	reachable[0] = true
+	furthest := 0
+	kind, start, end = Unsyllabifiable, 0, len(letters)
+	for i := range letters {
+		if reachable[i] {
+			furthest = i
+			kind, start, end = Unsyllabifiable, i, len(letters)
+		}
+		for _, template := range p.templates {
+			if !reachable[i] {
+				break
+			}
+			for _, m := range template.matches(p.alphabet, letters[i:], template.nucleus) {
+				s := syllable{start: i, nucleus: i + m.nucleus, end: i + m.length}
+				switch p.allowed(letters[i:s.nucleus], letters[s.nucleus+1:s.end]) {
+				case 0:
+					reachable[s.end] = true
+				case IllegalOnset:
+					if kind == Unsyllabifiable && start == furthest {
+						kind, start, end = IllegalOnset, i, s.nucleus
+					}
+				case IllegalCoda:
+					if kind == Unsyllabifiable && start == furthest {
+						kind, start, end = IllegalCoda, s.nucleus+1, s.end
+					}
+				}
+			}
+		}
+	}
+	return kind, start, end
+}
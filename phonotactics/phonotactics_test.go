@@ -0,0 +1,130 @@
+package phonotactics
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+func testAlphabet() alphabet.Alphabet {
+	return alphabet.New([]alphabet.Letter{
+		alphabet.NewLetter("A", "a", 'V'),
+		alphabet.NewLetter("I", "i", 'V'),
+		alphabet.NewLetter("K", "k", 'C'),
+		alphabet.NewLetter("L", "l", 'C', 'L'),
+		alphabet.NewLetter("M", "m", 'C', 'N'),
+		alphabet.WithPositions(alphabet.NewLetter("NG", "ng", 'C', 'N'), alphabet.Coda),
+		alphabet.NewLetter("P", "p", 'C'),
+		alphabet.NewLetter("R", "r", 'C', 'L'),
+		alphabet.NewLetter("S", "s", 'C', 'S'),
+		alphabet.NewLetter("T", "t", 'C'),
+	}, alphabet.WithShorthand('L', "liquid"), alphabet.WithShorthand('N', "nasal"), alphabet.WithShorthand('S', "sibilant"))
+}
+
+func TestTemplate(t *testing.T) {
+	for _, testCase := range []struct {
+		Input  string
+		Output string
+		Err    bool
+	}{
+		{"CV", "CV", false},
+		{"(C)(L)V(N)", "(C)(L)V(N)", false},
+		{"(CL)V", "(C)(L)V", false},
+		{"ŊV(Ŋ)", "ŊV(Ŋ)", false},
+		{"(C(L))V", "", true},
+		{"C)V", "", true},
+		{"(CV", "", true},
+		{"()", "", true},
+	} {
+		template, err := ParseTemplate(testCase.Input)
+		if (err != nil) != testCase.Err || err == nil && template.String() != testCase.Output {
+			t.Logf("Expected %q to parse as %q; got %q (%v)\n", testCase.Input, testCase.Output, template, err)
+			t.Fail()
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	p, err := New(testAlphabet(), Constraints{
+		Templates: []string{"(C)(L)V(N)", "(C)V(S)"},
+		Onsets:    []string{"pl", "pr", "kl", "kr", "tr"},
+		Banned:    []string{"ii", "#r"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		Word     string
+		Problems []string
+	}{
+		{"pla", nil},
+		{"Kramang", nil},
+		{"tangma", nil},
+		{"pas", nil},
+		{"tla", []string{`onset "tl" at byte offset 0 is not allowed`}},
+		{"pasta", nil},
+		{"apt", []string{`"pt" at byte offset 1 fits no syllable template`}},
+		{"ngai", []string{`"ng" at byte offset 0 is initial, onset but may only be coda`}},
+		{"rakii", []string{`banned sequence "#r" at byte offset 0`, `banned sequence "ii" at byte offset 3`}},
+		{"", []string{`"" at byte offset 0 fits no syllable template`}},
+	} {
+		problems, err := p.Validate(testCase.Word)
+		if err != nil {
+			t.Log(testCase.Word, err)
+			t.Fail()
+			continue
+		}
+		got := make([]string, len(problems))
+		for i, problem := range problems {
+			got[i] = problem.String()
+		}
+		if !slices.Equal(got, testCase.Problems) && len(got)+len(testCase.Problems) > 0 {
+			t.Logf("Expected problems of %q to be %q; got %q\n", testCase.Word, testCase.Problems, got)
+			t.Fail()
+		}
+	}
+
+	if _, err := p.Validate("pax"); err == nil {
+		t.Log("Expected an error for a word outside the alphabet")
+		t.Fail()
+	}
+}
+
+func TestSyllables(t *testing.T) {
+	p, err := New(testAlphabet(), Constraints{
+		Templates: []string{"(C)V(N)", "CLV"},
+		Onsets:    []string{"pl", "kr"},
+		Banned:    []string{"ng", "ma"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	syllables := p.Syllables()
+	for _, syllable := range []string{"a", "am", "pla", "kri", "ti", "tim"} {
+		if !slices.Contains(syllables, syllable) {
+			t.Logf("Expected %q among the syllables %v\n", syllable, syllables)
+			t.Fail()
+		}
+	}
+	for _, syllable := range []string{"ang", "ma", "pra", "ngi", "tr"} {
+		if slices.Contains(syllables, syllable) {
+			t.Logf("Expected %q not to be among the syllables %v\n", syllable, syllables)
+			t.Fail()
+		}
+	}
+	for _, constraints := range []Constraints{
+		{},
+		{Templates: []string{"CC"}},
+		{Templates: []string{"C(V)"}},
+		{Templates: []string{"CV"}, Onsets: []string{"p"}},
+		{Templates: []string{"CV"}, Banned: []string{"#"}},
+	} {
+		if _, err := New(testAlphabet(), constraints); err == nil {
+			t.Logf("Expected an error for %+v\n", constraints)
+			t.Fail()
+		}
+	}
+}
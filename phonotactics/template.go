@@ -0,0 +1,105 @@
+package phonotactics
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Slot is a single place in a syllable Template, filled by one Letter of its
+// Class.
+type Slot struct {
+	Class    alphabet.Class
+	Optional bool
+}
+
+// Template is a syllable shape such as "CV" or "(C)(L)V(N)". Each rune is a
+// Class, usually a shorthand, and Classes in parentheses are optional.
+type Template []Slot
+
+// TemplateError is returned by ParseTemplate for a malformed Template.
+type TemplateError struct {
+	Template string
+	Offset   int
+	Message  string
+}
+
+func (e *TemplateError) Error() string {
+	return fmt.Sprintf("phonotactics: %s at byte offset %d of template %q", e.Message, e.Offset, e.Template)
+}
+
+// ParseTemplate parses a syllable Template. Parentheses may hold one Class or
+// several, as in "(CL)V", in which case each is optional on its own.
+func ParseTemplate(s string) (Template, error) {
+	template := Template{}
+	optional := false
+	for i, char := range s {
+		switch char {
+		case '(':
+			if optional {
+				return nil, &TemplateError{Template: s, Offset: i, Message: "nested '('"}
+			}
+			optional = true
+		case ')':
+			if !optional {
+				return nil, &TemplateError{Template: s, Offset: i, Message: "unexpected ')'"}
+			}
+			optional = false
+		case ' ', '\t':
+		default:
+			template = append(template, Slot{Class: alphabet.Class(char), Optional: optional})
+		}
+	}
+	if optional {
+		return nil, &TemplateError{Template: s, Offset: len(s), Message: "missing ')'"}
+	}
+	if len(template) == 0 {
+		return nil, &TemplateError{Template: s, Offset: 0, Message: "empty template"}
+	}
+	return template, nil
+}
+
+func (t Template) String() string {
+	var b strings.Builder
+	for _, slot := range t {
+		if slot.Optional {
+			fmt.Fprintf(&b, "(%c)", slot.Class)
+		} else {
+			fmt.Fprintf(&b, "%c", slot.Class)
+		}
+	}
+	return b.String()
+}
+
+// match is one way a Template can be filled from the start of some Letters.
+type match struct {
+	// length is the number of Letters used.
+	length int
+	// nucleus is the index of the nucleus among them.
+	nucleus int
+}
+
+// matches returns every way the Template can be filled from the start of the
+// Letters, given the index of its nucleus slot.
+func (t Template) matches(a alphabet.Alphabet, letters []alphabet.Letter, nucleusSlot int) []match {
+	var matches []match
+	var fill func(slot, used, nucleus int)
+	fill = func(slot, used, nucleus int) {
+		if slot == len(t) {
+			matches = append(matches, match{length: used, nucleus: nucleus})
+			return
+		}
+		if t[slot].Optional {
+			fill(slot+1, used, nucleus)
+		}
+		if used < len(letters) && a.HasClass(letters[used], t[slot].Class) {
+			if slot == nucleusSlot {
+				nucleus = used
+			}
+			fill(slot+1, used+1, nucleus)
+		}
+	}
+	fill(0, 0, -1)
+	return matches
+}
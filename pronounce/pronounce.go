@@ -0,0 +1,171 @@
+// Package pronounce converts words written in an Alphabet to broad IPA using
+// each Letter's IPA value and context-sensitive spelling rules.
+package pronounce
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Rule pronounces a spelling as IPA when its neighbours match the Rule's
+// context. A context is a class expression such as "V" or "[+front]" that the
+// neighbouring Letter must match, "#" for the edge of a word, or empty to match
+// anything.
+type Rule struct {
+	// Spelling is one or more Letters of the Alphabet, written as a string. It
+	// may not be empty.
+	Spelling string
+	Before   string
+	After    string
+	IPA      string
+}
+
+func (r Rule) String() string {
+	before, after := r.Before, r.After
+	if before != "" {
+		before += " "
+	}
+	if after != "" {
+		after = " " + after
+	}
+	return fmt.Sprintf("%s > %s / %s_%s", r.Spelling, r.IPA, before, after)
+}
+
+// Error is returned when a Letter has no IPA value and no Rule pronounces it.
+type Error struct {
+	Word   string
+	Offset int
+	Text   string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("pronounce: no pronunciation for %q at byte offset %d of %q", e.Text, e.Offset, e.Word)
+}
+
+// Pronouncer converts words in an Alphabet to IPA.
+type Pronouncer struct {
+	alphabet alphabet.Alphabet
+	rules    []compiledRule
+}
+
+type compiledRule struct {
+	Rule
+	spelling      []alphabet.Letter
+	before, after context
+}
+
+// context is a parsed Rule context.
+type context struct {
+	boundary bool
+	expr     *alphabet.ClassExpr
+}
+
+func parseContext(s string) (context, error) {
+	switch s = strings.TrimSpace(s); s {
+	case "":
+		return context{}, nil
+	case string(alphabet.Boundary):
+		return context{boundary: true}, nil
+	}
+	expr, err := alphabet.ParseClassExpr(s)
+	return context{expr: &expr}, err
+}
+
+// matches checks the segment at i, which may be outside the word.
+func (c context) matches(a alphabet.Alphabet, segments []alphabet.Segment, i int) bool {
+	atBoundary := i < 0 || i >= len(segments) || segments[i].Letter == nil
+	switch {
+	case c.boundary:
+		return atBoundary
+	case c.expr != nil:
+		return !atBoundary && c.expr.Matches(a, segments[i].Letter)
+	}
+	return true
+}
+
+// New makes a Pronouncer for the given Alphabet. Rules with longer spellings
+// are tried first; among Rules of the same length the first one given wins.
+// Letters that no Rule matches are pronounced using their IPA value.
+func New(a alphabet.Alphabet, rules ...Rule) (*Pronouncer, error) {
+	p := &Pronouncer{alphabet: a}
+	for _, rule := range rules {
+		// longest-match is what Pronounce uses too, so ambiguity is fine here
+		spelling, err := a.Tokenize(strings.ToLower(rule.Spelling))
+		var ambiguous *alphabet.AmbiguousError
+		if err != nil && !errors.As(err, &ambiguous) {
+			return nil, fmt.Errorf("pronounce: rule %s: %w", rule, err)
+		}
+		if len(spelling) == 0 {
+			return nil, fmt.Errorf("pronounce: rule %s: empty spelling", rule)
+		}
+		compiled := compiledRule{Rule: rule, spelling: spelling}
+		if compiled.before, err = parseContext(rule.Before); err != nil {
+			return nil, fmt.Errorf("pronounce: rule %s: %w", rule, err)
+		}
+		if compiled.after, err = parseContext(rule.After); err != nil {
+			return nil, fmt.Errorf("pronounce: rule %s: %w", rule, err)
+		}
+		p.rules = append(p.rules, compiled)
+	}
+	slices.SortStableFunc(p.rules, func(a, b compiledRule) int { return len(b.spelling) - len(a.spelling) })
+	return p, nil
+}
+
+// Pronounce returns the broad IPA transcription of the given text. Text that is
+// not part of the Alphabet, such as spaces, is copied as is and separates
+// words.
+func (p *Pronouncer) Pronounce(text string) (string, error) {
+	var b strings.Builder
+	segments := p.alphabet.Segment(text)
+	for i := 0; i < len(segments); {
+		segment := segments[i]
+		if segment.Letter == nil {
+			b.WriteString(segment.Text)
+			i++
+			continue
+		}
+
+		if rule, ok := p.findRule(segments, i); ok {
+			b.WriteString(rule.IPA)
+			i += len(rule.spelling)
+			continue
+		}
+
+		if segment.Letter.IPA() == "" {
+			return "", &Error{Word: text, Offset: segment.Offset, Text: segment.Text}
+		}
+		b.WriteString(segment.Letter.IPA())
+		i++
+	}
+	return b.String(), nil
+}
+
+// XSAMPA returns the Pronounce transcription of the given text in X-SAMPA.
+func (p *Pronouncer) XSAMPA(text string) (string, error) {
+	ipa, err := p.Pronounce(text)
+	return alphabet.XSAMPA(ipa), err
+}
+
+func (p *Pronouncer) findRule(segments []alphabet.Segment, i int) (compiledRule, bool) {
+	for _, rule := range p.rules {
+		end := i + len(rule.spelling)
+		if end > len(segments) {
+			continue
+		}
+		matched := true
+		for j, letter := range rule.spelling {
+			if segments[i+j].Letter == nil || segments[i+j].Letter.Lower() != letter.Lower() {
+				matched = false
+				break
+			}
+		}
+		if matched && rule.before.matches(p.alphabet, segments, i-1) && rule.after.matches(p.alphabet, segments, end) {
+			return rule, true
+		}
+	}
+	return compiledRule{}, false
+}
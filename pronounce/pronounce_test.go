@@ -0,0 +1,74 @@
+package pronounce
+
+import (
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+func TestPronounce(t *testing.T) {
+	letter := func(upper, lower, ipa string) alphabet.Letter {
+		return alphabet.WithIPA(alphabet.NewLetter(upper, lower), ipa)
+	}
+	a := alphabet.New([]alphabet.Letter{
+		letter("A", "a", "a"),
+		letter("C", "c", "k"),
+		letter("CH", "ch", "tʃ"),
+		letter("E", "e", "e"),
+		letter("I", "i", "i"),
+		letter("L", "l", "l"),
+		letter("O", "o", "o"),
+		letter("S", "s", "s"),
+		alphabet.NewLetter("H", "h"),
+	})
+
+	p, err := New(a,
+		Rule{Spelling: "c", After: "[+front]", IPA: "s"},
+		Rule{Spelling: "ll", IPA: "ʎ"},
+		Rule{Spelling: "e", Before: "[-syllabic]", After: "#", IPA: "ə"},
+		Rule{Spelling: "s", Before: "[+syllabic]", After: "[+syllabic]", IPA: "z"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		Input  string
+		IPA    string
+		XSAMPA string
+	}{
+		{"cace", "kasə", "kas@"},
+		{"Cice", "sisə", "sis@"},
+		{"calle", "kaʎə", "kaL@"},
+		{"chose", "tʃozə", "tSoz@"},
+		{"casa ce", "kaza sə", "kaza s@"},
+	} {
+		ipa, err := p.Pronounce(testCase.Input)
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		if ipa != testCase.IPA {
+			t.Logf("Expected %q to be pronounced %q; got %q\n", testCase.Input, testCase.IPA, ipa)
+			t.Fail()
+		}
+		if xsampa, _ := p.XSAMPA(testCase.Input); xsampa != testCase.XSAMPA {
+			t.Logf("Expected %q to be pronounced %q in X-SAMPA; got %q\n", testCase.Input, testCase.XSAMPA, xsampa)
+			t.Fail()
+		}
+	}
+
+	if _, err := p.Pronounce("hola"); err == nil {
+		t.Log("Expected a letter without IPA to fail")
+		t.Fail()
+	}
+	if _, err := New(a, Rule{Spelling: "c", After: "[+front", IPA: "s"}); err == nil {
+		t.Log("Expected a malformed context to fail")
+		t.Fail()
+	}
+	if _, err := New(a, Rule{Spelling: "", IPA: "ʔ"}); err == nil {
+		t.Log("Expected an empty spelling to fail")
+		t.Fail()
+	}
+}
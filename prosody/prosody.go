@@ -0,0 +1,177 @@
+// Package prosody assigns stress to syllabified words and renders it in IPA or
+// with accent marks.
+package prosody
+
+import (
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/syllabify"
+)
+
+// Level is the stress of a single syllable.
+type Level int
+
+// Levels of stress, from weakest to strongest.
+const (
+	Unstressed Level = iota
+	Secondary
+	Primary
+)
+
+func (l Level) String() string {
+	switch l {
+	case Unstressed:
+		return "unstressed"
+	case Secondary:
+		return "secondary"
+	case Primary:
+		return "primary"
+	}
+	return "???"
+}
+
+// Position is the syllable a fixed stress system stresses, counted from one
+// edge of the word.
+type Position int
+
+// Positions of fixed stress.
+const (
+	Initial Position = iota
+	Peninitial
+	Antepenultimate
+	Penultimate
+	Final
+)
+
+// fromStart returns whether p is counted from the start of the word and how
+// many syllables in from that edge it is.
+func (p Position) fromStart() (bool, int) {
+	switch p {
+	case Initial:
+		return true, 0
+	case Peninitial:
+		return true, 1
+	case Antepenultimate:
+		return false, 2
+	case Penultimate:
+		return false, 1
+	}
+	return false, 0
+}
+
+// Weight is how syllable weight affects primary stress.
+type Weight int
+
+const (
+	// Insensitive ignores syllable weight.
+	Insensitive Weight = iota
+	// Bounded stresses the syllable at the Position if it is heavy, and
+	// otherwise the next syllable away from the edge, as in Latin.
+	Bounded
+	// Unbounded stresses the heavy syllable nearest the edge of the Position,
+	// or the syllable at the Position if none is heavy.
+	Unbounded
+)
+
+// Rhythm is the pattern of secondary stress.
+type Rhythm int
+
+const (
+	// NoSecondary assigns no secondary stress.
+	NoSecondary Rhythm = iota
+	// Alternating stresses every second syllable outwards from the primary
+	// stress.
+	Alternating
+	// InitialSecondary stresses the first syllable unless it is next to the
+	// primary stress.
+	InitialSecondary
+)
+
+// System is a declarative stress system.
+type System struct {
+	Position Position
+	Weight   Weight
+	Rhythm   Rhythm
+	// Heavy decides the weight of a syllable. If nil, syllables with a coda
+	// or a [+long] nucleus are heavy.
+	Heavy func(syllabify.Syllable) bool
+	// Marked finds syllables stressed in the lexicon. The first marked
+	// syllable takes primary stress regardless of the Position and Weight.
+	Marked func(syllabify.Syllable) bool
+}
+
+// IsHeavy is the default syllable weight: syllables with a coda or a [+long]
+// nucleus are heavy.
+func IsHeavy(s syllabify.Syllable) bool {
+	return len(s.Coda()) > 0 || s.Letters[s.Nucleus].Features()[alphabet.Long]
+}
+
+// MarkedBy returns a Marked function for syllables whose nucleus belongs to a
+// Class, such as one holding accented vowels.
+func MarkedBy(a alphabet.Alphabet, c alphabet.Class) func(syllabify.Syllable) bool {
+	return func(s syllabify.Syllable) bool { return a.HasClass(s.Letters[s.Nucleus], c) }
+}
+
+// Assign returns the stress Level of each syllable.
+func (s System) Assign(syllables []syllabify.Syllable) []Level {
+	levels := make([]Level, len(syllables))
+	if len(syllables) == 0 {
+		return levels
+	}
+	primary := s.primary(syllables)
+	levels[primary] = Primary
+
+	switch s.Rhythm {
+	case Alternating:
+		for i := primary - 2; i >= 0; i -= 2 {
+			levels[i] = Secondary
+		}
+		for i := primary + 2; i < len(syllables); i += 2 {
+			levels[i] = Secondary
+		}
+	case InitialSecondary:
+		if primary > 1 {
+			levels[0] = Secondary
+		}
+	}
+	return levels
+}
+
+// primary returns the index of the syllable with primary stress.
+func (s System) primary(syllables []syllabify.Syllable) int {
+	if s.Marked != nil {
+		for i, syllable := range syllables {
+			if s.Marked(syllable) {
+				return i
+			}
+		}
+	}
+
+	heavy := s.Heavy
+	if heavy == nil {
+		heavy = IsHeavy
+	}
+	n := len(syllables)
+	fromStart, steps := s.Position.fromStart()
+	// index counts syllables in from the edge of the Position
+	index := func(i int) int {
+		i = min(i, n-1)
+		if fromStart {
+			return i
+		}
+		return n - 1 - i
+	}
+
+	switch s.Weight {
+	case Bounded:
+		if !heavy(syllables[index(steps)]) && steps+1 < n {
+			return index(steps + 1)
+		}
+	case Unbounded:
+		for i := 0; i < n; i++ {
+			if heavy(syllables[index(i)]) {
+				return index(i)
+			}
+		}
+	}
+	return index(steps)
+}
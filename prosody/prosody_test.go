@@ -0,0 +1,127 @@
+package prosody
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/syllabify"
+)
+
+func testAlphabet() alphabet.Alphabet {
+	letter := func(upper, lower, ipa string, classes ...alphabet.Class) alphabet.Letter {
+		return alphabet.WithIPA(alphabet.NewLetter(upper, lower, classes...), ipa)
+	}
+	return alphabet.New([]alphabet.Letter{
+		letter("A", "a", "a", 'V'),
+		letter("\u00c1", "\u00e1", "a", 'V', 'A'),
+		letter("AA", "aa", "aː", 'V'),
+		letter("E", "e", "e", 'V'),
+		letter("I", "i", "i", 'V'),
+		letter("O", "o", "o", 'V'),
+		letter("K", "k", "k", 'C'),
+		letter("M", "m", "m", 'C'),
+		letter("N", "n", "n", 'C'),
+		letter("R", "r", "r", 'C'),
+		letter("S", "s", "s", 'C'),
+		letter("SH", "sh", "ʃ", 'C'),
+		letter("T", "t", "t", 'C'),
+	})
+}
+
+func TestAssign(t *testing.T) {
+	a := testAlphabet()
+	s, err := syllabify.New(a, syllabify.Principles{MaximalOnset: true, Onsets: []string{"kr", "tr"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		Name   string
+		System System
+		Words  map[string]string
+	}{
+		{"initial", System{Position: Initial}, map[string]string{
+			"kata":     "ˈka.ta",
+			"e":        "ˈe",
+			"kamitoro": "ˈka.mi.to.ro",
+		}},
+		{"penultimate", System{Position: Penultimate}, map[string]string{
+			"kata":   "ˈka.ta",
+			"e":      "ˈe",
+			"samita": "saˈmi.ta",
+		}},
+		{"final", System{Position: Final, Rhythm: Alternating}, map[string]string{
+			"kamitoro": "kaˌmi.toˈro",
+			"samita":   "ˌsa.miˈta",
+		}},
+		{"bounded", System{Position: Penultimate, Weight: Bounded}, map[string]string{
+			"samita":  "ˈsa.mi.ta",
+			"samanta": "saˈman.ta",
+			"samaata": "saˈmaː.ta",
+			"kata":    "ˈka.ta",
+		}},
+		{"unbounded", System{Position: Final, Weight: Unbounded}, map[string]string{
+			"kamitoro": "ka.mi.toˈro",
+			"kanmita":  "ˈkan.mi.ta",
+			"kanmitas": "kan.miˈtas",
+		}},
+		{"marked", System{Position: Penultimate, Rhythm: InitialSecondary, Marked: MarkedBy(a, 'A')}, map[string]string{
+			"kamitoro":      "ˌka.miˈto.ro",
+			"kamitor\u00e1": "ˌka.mi.toˈra",
+			"k\u00e1mitoro": "ˈka.mi.to.ro",
+		}},
+	} {
+		for word, expected := range testCase.Words {
+			syllables, err := s.SyllabifyWord(word)
+			if err != nil {
+				t.Log(err)
+				t.Fail()
+				continue
+			}
+			if ipa := IPA(syllables, testCase.System.Assign(syllables)); ipa != expected {
+				t.Logf("Expected %s stress on %q to be %q; got %q\n", testCase.Name, word, expected, ipa)
+				t.Fail()
+			}
+		}
+	}
+
+	if levels := (System{}).Assign(nil); len(levels) != 0 {
+		t.Logf("Expected no levels for no syllables; got %v\n", levels)
+		t.Fail()
+	}
+}
+
+func TestAccent(t *testing.T) {
+	a := testAlphabet()
+	s, _ := syllabify.New(a, syllabify.Principles{MaximalOnset: true, Onsets: []string{"kr", "tr"}})
+	system := System{Position: Penultimate, Rhythm: Alternating}
+
+	for _, testCase := range []struct {
+		Word     string
+		Expected string
+	}{
+		{"samita", "sami\u0301ta"},
+		{"Kata", "K\u00e1ta"},
+		{"Ata", "\u00c1ta"},
+		{"kamitoro", "ka\u0300mito\u0301ro"},
+	} {
+		syllables, err := s.SyllabifyWord(testCase.Word)
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		levels := system.Assign(syllables)
+		if accented := Accent(a, syllables, levels, Acute, Grave); accented != testCase.Expected {
+			t.Logf("Expected %q to be accented as %q; got %q (%v)\n", testCase.Word, testCase.Expected, accented, levels)
+			t.Fail()
+		}
+	}
+
+	syllables, _ := s.SyllabifyWord("sasha")
+	if levels := system.Assign(syllables); !slices.Equal(levels, []Level{Primary, Unstressed}) {
+		t.Logf("Expected penultimate stress on \"sasha\"; got %v\n", levels)
+		t.Fail()
+	}
+}
@@ -0,0 +1,67 @@
+package prosody
+
+import (
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/syllabify"
+)
+
+// Acute and Grave are the combining accents commonly used to mark stress.
+const (
+	Acute = '\u0301'
+	Grave = '\u0300'
+)
+
+// IPA renders syllables in IPA with ˈ before primary and ˌ before secondary
+// stress, separating other syllables with ".". Letters without an IPA value
+// are written in their lower form.
+func IPA(syllables []syllabify.Syllable, levels []Level) string {
+	var b strings.Builder
+	for i, syllable := range syllables {
+		switch {
+		case i < len(levels) && levels[i] == Primary:
+			b.WriteString("ˈ")
+		case i < len(levels) && levels[i] == Secondary:
+			b.WriteString("ˌ")
+		case i > 0:
+			b.WriteString(".")
+		}
+		for _, letter := range syllable.Letters {
+			if letter.IPA() != "" {
+				b.WriteString(letter.IPA())
+			} else {
+				b.WriteString(letter.Lower())
+			}
+		}
+	}
+	return b.String()
+}
+
+// Accent writes syllables with a mark on the nucleus of each stressed syllable:
+// primary on the primary stress and secondary, unless it is zero, on secondary
+// stresses. A marked nucleus is written as the Letter of the Alphabet spelled
+// by the nucleus and the mark, such as "á" for "a" and Acute, or with the mark
+// appended if the Alphabet has no such Letter. Syllables keep their original
+// spelling where they have one.
+func Accent(a alphabet.Alphabet, syllables []syllabify.Syllable, levels []Level, primary, secondary rune) string {
+	var b strings.Builder
+	for i, syllable := range syllables {
+		mark := rune(0)
+		if i < len(levels) && levels[i] == Primary {
+			mark = primary
+		} else if i < len(levels) && levels[i] == Secondary {
+			mark = secondary
+		}
+
+		segments := a.Segment(syllable.String())
+		for j, segment := range segments {
+			if j != syllable.Nucleus || mark == 0 || segment.Letter == nil {
+				b.WriteString(segment.Text)
+				continue
+			}
+			b.WriteString(alphabet.Mark(a, segment, string(mark)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,132 @@
+package script
+
+import (
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Abugida is a writing system where each consonant glyph carries an inherent
+// vowel that other vowels replace with a dependent sign, like Devanagari or
+// Ge'ez. Phonemes are identified by the lower form of their Letter.
+type Abugida struct {
+	// Consonants maps a consonant to its base glyph.
+	Consonants map[string]string
+	// InherentVowel is the vowel a bare consonant glyph is read with.
+	InherentVowel string
+	// VowelSigns maps a vowel to the sign written after a consonant glyph to
+	// replace the inherent vowel.
+	VowelSigns map[string]string
+	// Vowels maps a vowel to the independent glyph used when it does not
+	// follow a consonant.
+	Vowels map[string]string
+	// Virama is the vowel killer sign that silences the inherent vowel of a
+	// consonant that is not followed by a vowel.
+	Virama string
+	// Finals maps a consonant to a sign used in place of consonant+virama
+	// when it closes a syllable, such as the anusvara for a final nasal.
+	Finals map[string]string
+}
+
+func (a Abugida) isVowel(phoneme string) bool {
+	if phoneme == a.InherentVowel {
+		return true
+	}
+	_, sign := a.VowelSigns[phoneme]
+	_, independent := a.Vowels[phoneme]
+	return sign || independent
+}
+
+// Encode spells a sequence of phonemes in consonant glyphs, vowel signs and
+// independent vowels.
+func (a Abugida) Encode(letters []alphabet.Letter) (string, error) {
+	var b strings.Builder
+	phonemes := lowerForms(letters)
+	for i := 0; i < len(phonemes); i++ {
+		phoneme := phonemes[i]
+		if a.isVowel(phoneme) {
+			glyph, ok := a.Vowels[phoneme]
+			if !ok {
+				return "", &Error{Offset: i, Text: phoneme, Message: "no independent glyph for vowel"}
+			}
+			b.WriteString(glyph)
+			continue
+		}
+
+		followedByVowel := i+1 < len(phonemes) && a.isVowel(phonemes[i+1])
+		if final, ok := a.Finals[phoneme]; ok && !followedByVowel && i > 0 && a.isVowel(phonemes[i-1]) {
+			b.WriteString(final)
+			continue
+		}
+
+		glyph, ok := a.Consonants[phoneme]
+		if !ok {
+			return "", &Error{Offset: i, Text: phoneme, Message: "no glyph for consonant"}
+		}
+		b.WriteString(glyph)
+
+		switch {
+		case !followedByVowel:
+			if a.Virama == "" {
+				return "", &Error{Offset: i, Text: phoneme, Message: "no virama for consonant without a vowel"}
+			}
+			b.WriteString(a.Virama)
+		case phonemes[i+1] == a.InherentVowel:
+			i++
+		default:
+			sign, ok := a.VowelSigns[phonemes[i+1]]
+			if !ok {
+				return "", &Error{Offset: i + 1, Text: phonemes[i+1], Message: "no vowel sign for"}
+			}
+			b.WriteString(sign)
+			i++
+		}
+	}
+	return b.String(), nil
+}
+
+// Decode converts consonant glyphs, vowel signs and independent vowels back to
+// phonemes, restoring the inherent vowel after bare consonants.
+func (a Abugida) Decode(glyphs string) (string, error) {
+	consonants := invert(a.Consonants)
+	signs := invert(a.VowelSigns)
+	vowels := invert(a.Vowels)
+	finals := invert(a.Finals)
+	reader := newGlyphReader(a.Consonants, a.VowelSigns, a.Vowels, a.Finals)
+	reader.add(a.Virama)
+
+	var b strings.Builder
+	for i := 0; i < len(glyphs); {
+		glyph := reader.match(glyphs[i:])
+		i += len(glyph)
+
+		if consonant, ok := consonants[glyph]; ok {
+			b.WriteString(consonant)
+			next := reader.match(glyphs[i:])
+			if sign, ok := signs[next]; ok && next != "" {
+				b.WriteString(sign)
+				i += len(next)
+			} else if next != "" && next == a.Virama {
+				i += len(next)
+			} else {
+				b.WriteString(a.InherentVowel)
+			}
+			continue
+		}
+		if vowel, ok := vowels[glyph]; ok {
+			b.WriteString(vowel)
+			continue
+		}
+		if final, ok := finals[glyph]; ok {
+			b.WriteString(final)
+			continue
+		}
+
+		offset := i - len(glyph)
+		if glyph == "" {
+			glyph = firstRune(glyphs[offset:])
+		}
+		return "", &Error{Offset: offset, Text: glyph, Message: "unexpected glyph"}
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,87 @@
+// Package script provides writing systems other than alphabets, such as
+// syllabaries and abugidas, that spell a sequence of phonemes written in an
+// alphabet.Alphabet using glyphs.
+package script
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// WritingSystem converts a phonemic sequence to glyphs and back.
+type WritingSystem interface {
+	// Encode spells a sequence of phonemes in glyphs.
+	Encode([]alphabet.Letter) (string, error)
+	// Decode converts glyphs back to phonemes, written as the lower forms of
+	// the phonemic Letters.
+	Decode(string) (string, error)
+}
+
+// Error reports a phoneme or glyph that a WritingSystem cannot convert.
+type Error struct {
+	// Offset is the index of the phoneme when encoding, or the byte offset of
+	// the glyph when decoding.
+	Offset  int
+	Text    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("script: %s %q at offset %d", e.Message, e.Text, e.Offset)
+}
+
+// glyphReader matches glyph strings longest first.
+type glyphReader struct {
+	glyphs  map[string]bool
+	longest int
+}
+
+func newGlyphReader(maps ...map[string]string) glyphReader {
+	reader := glyphReader{glyphs: map[string]bool{}}
+	for _, m := range maps {
+		for _, glyph := range m {
+			reader.add(glyph)
+		}
+	}
+	return reader
+}
+
+func (g *glyphReader) add(glyph string) {
+	if glyph == "" {
+		return
+	}
+	g.glyphs[glyph] = true
+	g.longest = max(g.longest, len(glyph))
+}
+
+// match returns the longest known glyph at the start of s.
+func (g glyphReader) match(s string) string {
+	for n := min(g.longest, len(s)); n > 0; n-- {
+		if g.glyphs[s[:n]] {
+			return s[:n]
+		}
+	}
+	return ""
+}
+
+// invert maps glyphs back to the phonemes they spell. When several phonemes
+// share a glyph, the alphabetically first one wins so results are stable.
+func invert(m map[string]string) map[string]string {
+	inverse := map[string]string{}
+	for phoneme, glyph := range m {
+		if existing, ok := inverse[glyph]; !ok || phoneme < existing {
+			inverse[glyph] = phoneme
+		}
+	}
+	return inverse
+}
+
+func lowerForms(letters []alphabet.Letter) []string {
+	phonemes := make([]string, len(letters))
+	for i, letter := range letters {
+		phonemes[i] = strings.ToLower(letter.Lower())
+	}
+	return phonemes
+}
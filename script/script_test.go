@@ -0,0 +1,85 @@
+package script
+
+import (
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+func phonemes() alphabet.Alphabet {
+	return alphabet.New([]alphabet.Letter{
+		alphabet.NewLetter("A", "a", 'V'),
+		alphabet.NewLetter("I", "i", 'V'),
+		alphabet.NewLetter("U", "u", 'V'),
+		alphabet.NewLetter("K", "k", 'C'),
+		alphabet.NewLetter("M", "m", 'C'),
+		alphabet.NewLetter("N", "n", 'C'),
+		alphabet.NewLetter("T", "t", 'C'),
+	})
+}
+
+func roundTrip(t *testing.T, name string, system WritingSystem, word, glyphs, back string) {
+	letters, err := phonemes().Tokenize(word)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := system.Encode(letters)
+	if err != nil {
+		t.Logf("%s: %v\n", name, err)
+		t.Fail()
+		return
+	}
+	if encoded != glyphs {
+		t.Logf("%s: expected %q to be written %q; got %q\n", name, word, glyphs, encoded)
+		t.Fail()
+	}
+	if decoded, err := system.Decode(encoded); err != nil || decoded != back {
+		t.Logf("%s: expected %q to be read %q; got %q (%v)\n", name, encoded, back, decoded, err)
+		t.Fail()
+	}
+}
+
+func TestSyllabary(t *testing.T) {
+	kana := Syllabary{
+		Vowels: map[string]string{"a": "あ", "i": "い", "u": "う"},
+		Syllables: map[[2]string]string{
+			{"k", "a"}: "か", {"k", "i"}: "き", {"k", "u"}: "く",
+			{"t", "a"}: "た", {"t", "i"}: "ち", {"t", "u"}: "つ",
+			{"n", "a"}: "な", {"n", "i"}: "に", {"n", "u"}: "ぬ",
+			{"m", "a"}: "ま", {"m", "i"}: "み", {"m", "u"}: "む",
+		},
+		Finals:    map[string]string{"n": "ん"},
+		EchoVowel: "u",
+	}
+
+	roundTrip(t, "kana", kana, "kana", "かな", "kana")
+	roundTrip(t, "kana", kana, "ikan", "いかん", "ikan")
+	roundTrip(t, "kana", kana, "aikit", "あいきつ", "aikitu")
+
+	kana.EchoVowel = ""
+	if _, err := kana.Encode([]alphabet.Letter{alphabet.NewLetter("T", "t")}); err == nil {
+		t.Log("Expected a final consonant without a glyph to fail")
+		t.Fail()
+	}
+	if _, err := kana.Decode("かX"); err == nil {
+		t.Log("Expected an unknown glyph to fail")
+		t.Fail()
+	}
+}
+
+func TestAbugida(t *testing.T) {
+	devanagari := Abugida{
+		Consonants:    map[string]string{"k": "क", "t": "त", "n": "न", "m": "म"},
+		InherentVowel: "a",
+		VowelSigns:    map[string]string{"i": "ि", "u": "ु"},
+		Vowels:        map[string]string{"a": "अ", "i": "इ", "u": "उ"},
+		Virama:        "्",
+		Finals:        map[string]string{"m": "ं"},
+	}
+
+	roundTrip(t, "devanagari", devanagari, "kitu", "कितु", "kitu")
+	roundTrip(t, "devanagari", devanagari, "akka", "अक्क", "akka")
+	roundTrip(t, "devanagari", devanagari, "kamtak", "कंतक्", "kamtak")
+	roundTrip(t, "devanagari", devanagari, "mata", "मत", "mata")
+	roundTrip(t, "devanagari", devanagari, "kiu", "किउ", "kiu")
+}
@@ -0,0 +1,106 @@
+package script
+
+import (
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Syllabary is a writing system with one glyph per syllable, like Japanese
+// kana or Cherokee. Phonemes are identified by the lower form of their Letter.
+type Syllabary struct {
+	// Vowels maps a vowel to the glyph for the vowel on its own.
+	Vowels map[string]string
+	// Syllables maps a consonant and a vowel to the glyph for the syllable.
+	Syllables map[[2]string]string
+	// Finals maps a consonant to the glyph used when it closes a syllable,
+	// such as Japanese ん for "n".
+	Finals map[string]string
+	// EchoVowel, if set, spells a consonant that is not followed by a vowel
+	// and has no Finals glyph using its syllable with this vowel, the way
+	// Cherokee and Linear B do. Such vowels cannot be told apart from real
+	// ones when decoding.
+	EchoVowel string
+}
+
+func (s Syllabary) isVowel(phoneme string) bool {
+	if _, ok := s.Vowels[phoneme]; ok {
+		return true
+	}
+	for syllable := range s.Syllables {
+		if syllable[1] == phoneme {
+			return true
+		}
+	}
+	return false
+}
+
+// Encode spells a sequence of phonemes in syllable glyphs.
+func (s Syllabary) Encode(letters []alphabet.Letter) (string, error) {
+	var b strings.Builder
+	phonemes := lowerForms(letters)
+	for i := 0; i < len(phonemes); i++ {
+		phoneme := phonemes[i]
+		if s.isVowel(phoneme) {
+			glyph, ok := s.Vowels[phoneme]
+			if !ok {
+				return "", &Error{Offset: i, Text: phoneme, Message: "no glyph for lone vowel"}
+			}
+			b.WriteString(glyph)
+			continue
+		}
+
+		if i+1 < len(phonemes) && s.isVowel(phonemes[i+1]) {
+			glyph, ok := s.Syllables[[2]string{phoneme, phonemes[i+1]}]
+			if !ok {
+				return "", &Error{Offset: i, Text: phoneme + phonemes[i+1], Message: "no glyph for syllable"}
+			}
+			b.WriteString(glyph)
+			i++
+			continue
+		}
+
+		if glyph, ok := s.Finals[phoneme]; ok {
+			b.WriteString(glyph)
+		} else if glyph, ok := s.Syllables[[2]string{phoneme, s.EchoVowel}]; ok && s.EchoVowel != "" {
+			b.WriteString(glyph)
+		} else {
+			return "", &Error{Offset: i, Text: phoneme, Message: "no glyph for final consonant"}
+		}
+	}
+	return b.String(), nil
+}
+
+// Decode converts syllable glyphs back to phonemes.
+func (s Syllabary) Decode(glyphs string) (string, error) {
+	syllables := map[string]string{}
+	for syllable, glyph := range s.Syllables {
+		syllables[syllable[0]+syllable[1]] = glyph
+	}
+	inverse := invert(syllables)
+	for glyph, phoneme := range invert(s.Vowels) {
+		inverse[glyph] = phoneme
+	}
+	for glyph, phoneme := range invert(s.Finals) {
+		inverse[glyph] = phoneme
+	}
+	reader := newGlyphReader(syllables, s.Vowels, s.Finals)
+
+	var b strings.Builder
+	for i := 0; i < len(glyphs); {
+		glyph := reader.match(glyphs[i:])
+		if glyph == "" {
+			return "", &Error{Offset: i, Text: firstRune(glyphs[i:]), Message: "unknown glyph"}
+		}
+		b.WriteString(inverse[glyph])
+		i += len(glyph)
+	}
+	return b.String(), nil
+}
+
+func firstRune(s string) string {
+	for _, r := range s {
+		return string(r)
+	}
+	return ""
+}
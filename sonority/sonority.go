@@ -0,0 +1,68 @@
+// Package sonority ranks Letters on a sonority scale and checks that clusters
+// follow the Sonority Sequencing Principle: sonority rises through an onset
+// to the nucleus and falls through a coda away from it.
+package sonority
+
+import (
+	"fmt"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Level gives a sonority Value to the Letters matching a class expression,
+// such as "liquid" or "[+sonorant +consonantal -nasal]".
+type Level struct {
+	Class string
+	Value int
+}
+
+// Scale assigns a sonority to each Letter of an Alphabet.
+type Scale struct {
+	alphabet alphabet.Alphabet
+	levels   []Level
+	classes  []alphabet.ClassExpr
+}
+
+// New makes a Scale from Levels. A Letter takes the Value of the first Level
+// it matches, so more specific Levels should come first.
+func New(a alphabet.Alphabet, levels ...Level) (*Scale, error) {
+	s := &Scale{alphabet: a, levels: levels}
+	for _, level := range levels {
+		class, err := alphabet.ParseClassExpr(level.Class)
+		if err != nil {
+			return nil, fmt.Errorf("sonority: level %q: %w", level.Class, err)
+		}
+		s.classes = append(s.classes, class)
+	}
+	return s, nil
+}
+
+// DefaultLevels is a common sonority scale by Features: vowels, which may
+// instead be in the Class 'V', then glides, liquids, nasals, fricatives, and
+// stops and affricates.
+var DefaultLevels = []Level{
+	{"[+syllabic] | V", 6},
+	{"[-syllabic -consonantal +sonorant]", 5},
+	{"[+consonantal +sonorant -nasal]", 4},
+	{"[+nasal]", 3},
+	{"[-sonorant +continuant]", 2},
+	{"[-sonorant -continuant]", 1},
+}
+
+// Default makes a Scale of the DefaultLevels. It only returns an error if
+// DefaultLevels has been changed to hold an invalid Level.
+func Default(a alphabet.Alphabet) (*Scale, error) { return New(a, DefaultLevels...) }
+
+// Levels returns the Levels of the Scale.
+func (s *Scale) Levels() []Level { return s.levels }
+
+// Value returns the sonority of a Letter, or 0 if it matches no Level. It can
+// be used as the Sonority of syllabify.Principles.
+func (s *Scale) Value(l alphabet.Letter) int {
+	for i, class := range s.classes {
+		if class.Matches(s.alphabet, l) {
+			return s.levels[i].Value
+		}
+	}
+	return 0
+}
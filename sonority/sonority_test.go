@@ -0,0 +1,171 @@
+package sonority
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/syllabify"
+)
+
+func testAlphabet() alphabet.Alphabet {
+	letters := []alphabet.Letter{}
+	for _, symbol := range []string{"a", "i", "j", "k", "l", "m", "n", "p", "r", "s", "t"} {
+		class := alphabet.Class('C')
+		if symbol == "a" || symbol == "i" {
+			class = 'V'
+		}
+		letters = append(letters, alphabet.WithIPA(alphabet.NewLetter(symbol, symbol, class), symbol))
+	}
+	return alphabet.New(letters)
+}
+
+func tokenize(t *testing.T, a alphabet.Alphabet, s string) []alphabet.Letter {
+	letters, err := a.Tokenize(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return letters
+}
+
+func defaultScale(t *testing.T, a alphabet.Alphabet) *Scale {
+	s, err := Default(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestScale(t *testing.T) {
+	a := testAlphabet()
+	s := defaultScale(t, a)
+	for _, testCase := range []struct {
+		Letter string
+		Value  int
+	}{
+		{"a", 6}, {"j", 5}, {"l", 4}, {"r", 4}, {"m", 3}, {"s", 2}, {"t", 1},
+	} {
+		if value := s.Value(tokenize(t, a, testCase.Letter)[0]); value != testCase.Value {
+			t.Logf("Expected %q to have sonority %d; got %d\n", testCase.Letter, testCase.Value, value)
+			t.Fail()
+		}
+	}
+
+	b := alphabet.New([]alphabet.Letter{
+		alphabet.NewLetter("A", "a", 'V'),
+		alphabet.NewLetter("B", "b", 'C'),
+		alphabet.NewLetter("H", "h"),
+	})
+	custom, err := New(b, Level{"V", 2}, Level{"C", 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	letters := tokenize(t, b, "abh")
+	if custom.Value(letters[0]) != 2 || custom.Value(letters[1]) != 1 || custom.Value(letters[2]) != 0 {
+		t.Logf("Expected class levels to give sonority by class\n")
+		t.Fail()
+	}
+	if _, err := New(a, Level{"(C", 1}); err == nil {
+		t.Logf("Expected an error for an invalid level\n")
+		t.Fail()
+	}
+
+	defaults := DefaultLevels
+	DefaultLevels = []Level{{"(C", 1}}
+	if _, err := Default(a); err == nil {
+		t.Logf("Expected an error for invalid default levels\n")
+		t.Fail()
+	}
+	DefaultLevels = defaults
+}
+
+func TestChecker(t *testing.T) {
+	a := testAlphabet()
+	strict, err := NewChecker(defaultScale(t, a), Principle{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lenient, err := NewChecker(defaultScale(t, a), Principle{
+		Plateaus: true,
+		Onsets:   []string{"s[-sonorant -continuant]"},
+		Codas:    []string{"{C}s"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		Cluster string
+		Onset   bool
+		Strict  bool
+		Lenient bool
+	}{
+		{"pl", true, true, true},
+		{"lp", true, false, false},
+		{"st", true, false, true},
+		{"str", true, false, true},
+		{"stl", true, false, true},
+		{"slt", true, false, false},
+		{"pt", true, false, true},
+		{"lp", false, true, true},
+		{"pl", false, false, false},
+		{"ts", false, false, true},
+		{"mps", false, false, true},
+		{"pms", false, false, false},
+	} {
+		letters := tokenize(t, a, testCase.Cluster)
+		check := func(c *Checker) bool {
+			if testCase.Onset {
+				return c.CheckOnset(letters)
+			}
+			return c.CheckCoda(letters)
+		}
+		if check(strict) != testCase.Strict || check(lenient) != testCase.Lenient {
+			t.Logf("Expected %q (onset %v) to be %v strictly and %v leniently\n", testCase.Cluster, testCase.Onset, testCase.Strict, testCase.Lenient)
+			t.Fail()
+		}
+	}
+
+	for _, p := range []Principle{{Onsets: []string{"s"}}, {Codas: []string{"x{C}"}}, {Onsets: []string{"s{C"}}} {
+		if _, err := NewChecker(defaultScale(t, a), p); err == nil {
+			t.Logf("Expected an error for %v\n", p)
+			t.Fail()
+		}
+	}
+}
+
+func TestReport(t *testing.T) {
+	a := testAlphabet()
+	scale := defaultScale(t, a)
+	c, err := NewChecker(scale, Principle{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := syllabify.New(a, syllabify.Principles{MaximalOnset: true, Sonority: scale.Value})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations, err := c.Report(s, []string{"stapa", "pala", "lpa", "kalp", "tapts", "stiki", "astra"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		`onset "st" in stapa, stiki`,
+		`onset "lp" in lpa`,
+		`coda "pts" in tapts`,
+	}
+	output := []string{}
+	for _, v := range violations {
+		output = append(output, v.String())
+	}
+	if !slices.Equal(output, expected) {
+		t.Logf("Expected violations %q; got %q\n", expected, output)
+		t.Fail()
+	}
+
+	if _, err := c.Report(s, []string{"pst"}); err == nil {
+		t.Logf("Expected an error for a word with no nucleus\n")
+		t.Fail()
+	}
+}
@@ -0,0 +1,208 @@
+package sonority
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/syllabify"
+)
+
+// Principle configures the Sonority Sequencing Principle of a language.
+type Principle struct {
+	// Plateaus allows neighbouring Letters of a cluster to have the same
+	// sonority.
+	Plateaus bool
+	// Onsets and Codas are exceptions to the principle, such as "s{stop}" for
+	// /s/ before a stop in English onsets. An exception is a sequence of
+	// Letters and class expressions in braces or feature bundles in brackets,
+	// and may start an onset or end a coda; the rest of the cluster is still
+	// checked, including the Letter it shares with the exception.
+	Onsets []string
+	Codas  []string
+}
+
+// Checker checks clusters against a Scale.
+type Checker struct {
+	scale    *Scale
+	plateaus bool
+	onsets   [][]item
+	codas    [][]item
+}
+
+// item is a Letter or a class expression of an exception.
+type item struct {
+	letter alphabet.Letter
+	class  *alphabet.ClassExpr
+}
+
+// NewChecker makes a Checker for the Principle on a Scale.
+func NewChecker(scale *Scale, principle Principle) (*Checker, error) {
+	c := &Checker{scale: scale, plateaus: principle.Plateaus}
+	var err error
+	if c.onsets, err = c.exceptions("onset", principle.Onsets); err != nil {
+		return nil, err
+	}
+	if c.codas, err = c.exceptions("coda", principle.Codas); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Checker) exceptions(kind string, patterns []string) ([][]item, error) {
+	exceptions := [][]item{}
+	for _, pattern := range patterns {
+		items, err := c.parse(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("sonority: %s exception %q: %w", kind, pattern, err)
+		}
+		if len(items) < 2 {
+			return nil, fmt.Errorf("sonority: %s exception %q is not a cluster", kind, pattern)
+		}
+		exceptions = append(exceptions, items)
+	}
+	return exceptions, nil
+}
+
+func (c *Checker) parse(pattern string) ([]item, error) {
+	items := []item{}
+	for s := strings.TrimSpace(pattern); s != ""; s = strings.TrimSpace(s) {
+		if s[0] == '{' || s[0] == '[' {
+			closing := "}"
+			if s[0] == '[' {
+				closing = "]"
+			}
+			end := strings.Index(s, closing)
+			if end < 0 {
+				return nil, fmt.Errorf("missing %q", closing)
+			}
+			expr := s[:end+1]
+			if s[0] == '{' {
+				expr = s[1:end]
+			}
+			class, err := alphabet.ParseClassExpr(expr)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item{class: &class})
+			s = s[end+1:]
+			continue
+		}
+		segment := c.scale.alphabet.Segment(s)[0]
+		if segment.Letter == nil {
+			return nil, fmt.Errorf("unknown letter %q", segment.Text)
+		}
+		items = append(items, item{letter: segment.Letter})
+		s = s[len(segment.Text):]
+	}
+	return items, nil
+}
+
+func (i item) matches(a alphabet.Alphabet, l alphabet.Letter) bool {
+	if i.class != nil {
+		return i.class.Matches(a, l)
+	}
+	return i.letter.Lower() == l.Lower()
+}
+
+// matchesAt returns true if the exception matches the cluster from start.
+func (c *Checker) matchesAt(exception []item, cluster []alphabet.Letter, start int) bool {
+	if start < 0 || start+len(exception) > len(cluster) {
+		return false
+	}
+	for i, it := range exception {
+		if !it.matches(c.scale.alphabet, cluster[start+i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckOnset returns true if sonority rises through the onset cluster.
+func (c *Checker) CheckOnset(cluster []alphabet.Letter) bool {
+	for _, exception := range c.onsets {
+		if c.matchesAt(exception, cluster, 0) {
+			cluster = cluster[len(exception)-1:]
+			break
+		}
+	}
+	return c.rises(cluster)
+}
+
+// CheckCoda returns true if sonority falls through the coda cluster, which is
+// written from the nucleus outwards.
+func (c *Checker) CheckCoda(cluster []alphabet.Letter) bool {
+	for _, exception := range c.codas {
+		if start := len(cluster) - len(exception); c.matchesAt(exception, cluster, start) {
+			cluster = cluster[:start+1]
+			break
+		}
+	}
+	reversed := make([]alphabet.Letter, len(cluster))
+	for i, l := range cluster {
+		reversed[len(cluster)-1-i] = l
+	}
+	return c.rises(reversed)
+}
+
+func (c *Checker) rises(cluster []alphabet.Letter) bool {
+	for i := 1; i < len(cluster); i++ {
+		before, after := c.scale.Value(cluster[i-1]), c.scale.Value(cluster[i])
+		if after < before || after == before && !c.plateaus {
+			return false
+		}
+	}
+	return true
+}
+
+// Violation is a cluster that breaks the principle, with the words it is
+// found in.
+type Violation struct {
+	Cluster string
+	// Position is alphabet.Onset or alphabet.Coda.
+	Position alphabet.Position
+	Words    []string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %q in %s", v.Position, v.Cluster, strings.Join(v.Words, ", "))
+}
+
+// Report syllabifies every word of a lexicon and returns the onset and coda
+// clusters that violate the principle, in the order they are first found.
+func (c *Checker) Report(s *syllabify.Syllabifier, words []string) ([]Violation, error) {
+	violations := []Violation{}
+	index := map[string]int{}
+	add := func(cluster []alphabet.Letter, position alphabet.Position, word string) {
+		var b strings.Builder
+		for _, l := range cluster {
+			b.WriteString(l.Lower())
+		}
+		key := fmt.Sprint(position, b.String())
+		i, ok := index[key]
+		if !ok {
+			i = len(violations)
+			index[key] = i
+			violations = append(violations, Violation{Cluster: b.String(), Position: position})
+		}
+		if words := violations[i].Words; len(words) == 0 || words[len(words)-1] != word {
+			violations[i].Words = append(words, word)
+		}
+	}
+
+	for _, word := range words {
+		syllables, err := s.SyllabifyWord(word)
+		if err != nil {
+			return nil, err
+		}
+		for _, syllable := range syllables {
+			if onset := syllable.Onset(); len(onset) > 1 && !c.CheckOnset(onset) {
+				add(onset, alphabet.Onset, word)
+			}
+			if coda := syllable.Coda(); len(coda) > 1 && !c.CheckCoda(coda) {
+				add(coda, alphabet.Coda, word)
+			}
+		}
+	}
+	return violations, nil
+}
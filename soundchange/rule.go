@@ -0,0 +1,317 @@
+package soundchange
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Rule is a single parsed sound change, written as
+//
+//	target > replacement / environment ! exception
+//
+// The target and replacement are lists of alternatives separated by commas,
+// such as "p, t, k > b, d, g", where each alternative is a sequence of
+// elements. The replacement list has one alternative per target or a single
+// one for all of them. The environment and the optional exception are lists of
+// contexts like "V_V" or "_#" separated by commas; "_" stands for the target.
+//
+// Elements are Letters, written in lower case; Classes, written as a single
+// rune that is a Class or shorthand of the Alphabet such as "V"; class
+// expressions in braces such as "{C & !nasal}"; and feature bundles such as
+// "[+voice]". "#" is the edge of the word in a context and "0" is nothing, for
+// insertion or deletion. In a context, elements in parentheses are optional.
+//
+// In the replacement, a Class takes the Letter at the same position in it as
+// the matched Letter has in the target's Class, and a feature bundle changes
+// the matched Letter's Features using Alphabet.Modify. A leading "?" makes a
+// Rule optional.
+type Rule struct {
+	// Text is the Rule as written.
+	Text     string
+	Optional bool
+
+	targets      [][]element
+	replacements [][]element
+	environments []context
+	exceptions   []context
+}
+
+func (r Rule) String() string { return r.Text }
+
+type elementKind int
+
+const (
+	letterElement elementKind = iota
+	classElement
+	featureElement
+	boundaryElement
+)
+
+// element is a single part of a Rule that matches or produces one Letter.
+type element struct {
+	kind     elementKind
+	text     string
+	letter   alphabet.Letter
+	class    alphabet.ClassExpr
+	features alphabet.Features
+	optional bool
+}
+
+// context is an environment or exception, split around the target.
+type context struct {
+	before, after []element
+}
+
+// Error reports a Rule that cannot be parsed.
+type Error struct {
+	// Line is the line the Rule was read from, if any.
+	Line    int
+	Rule    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("soundchange: line %d: rule %q: %s", e.Line, e.Rule, e.Message)
+	}
+	return fmt.Sprintf("soundchange: rule %q: %s", e.Rule, e.Message)
+}
+
+// ParseRule parses a Rule written in the given Alphabet.
+func ParseRule(a alphabet.Alphabet, text string) (Rule, error) {
+	rule := Rule{Text: strings.TrimSpace(text)}
+	fail := func(format string, args ...any) (Rule, error) {
+		return Rule{}, &Error{Rule: rule.Text, Message: fmt.Sprintf(format, args...)}
+	}
+
+	s := rule.Text
+	if rest, ok := strings.CutPrefix(s, "?"); ok {
+		rule.Optional, s = true, rest
+	}
+	s, exception, hasException := cut(s, "!")
+	s, environment, hasEnvironment := cut(s, "/")
+	target, replacement, ok := cut(s, ">")
+	if !ok {
+		return fail("missing '>'")
+	}
+
+	var err error
+	if rule.targets, err = parseAlternatives(a, target); err != nil {
+		return fail("target: %s", err)
+	}
+	if rule.replacements, err = parseAlternatives(a, replacement); err != nil {
+		return fail("replacement: %s", err)
+	}
+	if len(rule.replacements) != 1 && len(rule.replacements) != len(rule.targets) {
+		return fail("%d replacements for %d targets", len(rule.replacements), len(rule.targets))
+	}
+	for _, target := range rule.targets {
+		for _, e := range target {
+			if e.kind == boundaryElement {
+				return fail("'#' in target")
+			}
+		}
+	}
+	for i, replacement := range rule.replacements {
+		for j, e := range replacement {
+			if e.kind == boundaryElement {
+				return fail("'#' in replacement")
+			}
+			if e.kind == letterElement {
+				continue
+			}
+			// classes and features in the replacement change a matched Letter
+			targets := rule.targets
+			if len(rule.replacements) > 1 {
+				targets = rule.targets[i : i+1]
+			}
+			for _, target := range targets {
+				if j >= len(target) {
+					return fail("%q has no corresponding target", e.text)
+				}
+			}
+		}
+	}
+
+	if hasEnvironment {
+		if rule.environments, err = parseContexts(a, environment); err != nil {
+			return fail("environment: %s", err)
+		}
+	}
+	if hasException {
+		if rule.exceptions, err = parseContexts(a, exception); err != nil {
+			return fail("exception: %s", err)
+		}
+	}
+	return rule, nil
+}
+
+func parseAlternatives(a alphabet.Alphabet, s string) ([][]element, error) {
+	alternatives := [][]element{}
+	for _, alternative := range split(s, ",") {
+		elements, err := parseElements(a, alternative, false)
+		if err != nil {
+			return nil, err
+		}
+		alternatives = append(alternatives, elements)
+	}
+	return alternatives, nil
+}
+
+func parseContexts(a alphabet.Alphabet, s string) ([]context, error) {
+	contexts := []context{}
+	for _, c := range split(s, ",") {
+		before, after, ok := cut(c, "_")
+		if !ok {
+			return nil, fmt.Errorf("missing '_' in %q", strings.TrimSpace(c))
+		}
+		var parsed context
+		var err error
+		if parsed.before, err = parseElements(a, before, true); err != nil {
+			return nil, err
+		}
+		if parsed.after, err = parseElements(a, after, true); err != nil {
+			return nil, err
+		}
+		contexts = append(contexts, parsed)
+	}
+	return contexts, nil
+}
+
+// parseElements parses a sequence of elements. "0" alone is an empty sequence.
+func parseElements(a alphabet.Alphabet, s string, allowOptional bool) ([]element, error) {
+	s = strings.TrimSpace(s)
+	elements := []element{}
+	if s == "0" {
+		return elements, nil
+	}
+	if s == "" && !allowOptional {
+		return nil, fmt.Errorf("empty sequence, use \"0\" for nothing")
+	}
+
+	classes := map[alphabet.Class]bool{}
+	for _, class := range a.GetClasses().ToSlice() {
+		classes[class] = true
+	}
+	for shorthand := range a.Shorthands() {
+		classes[alphabet.Class(shorthand)] = true
+	}
+
+	optional := false
+	for i := 0; i < len(s); {
+		char, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case char == ' ' || char == '\t':
+			i += size
+			continue
+		case char == '(' && allowOptional && !optional:
+			optional = true
+			i += size
+			continue
+		case char == ')' && optional:
+			optional = false
+			i += size
+			continue
+		}
+
+		var e element
+		switch {
+		case char == '#':
+			e = element{kind: boundaryElement, text: "#"}
+		case char == '[' || char == '{':
+			closing := "]"
+			if char == '{' {
+				closing = "}"
+			}
+			end := strings.Index(s[i:], closing)
+			if end < 0 {
+				return nil, fmt.Errorf("missing %q", closing)
+			}
+			size = end + 1
+			e = element{kind: classElement, text: s[i : i+size]}
+			expr := strings.TrimSuffix(strings.TrimPrefix(e.text, "{"), "}")
+			var err error
+			if e.class, err = alphabet.ParseClassExpr(expr); err != nil {
+				return nil, err
+			}
+			if char == '[' {
+				e.kind = featureElement
+				if e.features, err = alphabet.ParseFeatures(e.text); err != nil {
+					return nil, err
+				}
+			}
+		case classes[alphabet.Class(char)]:
+			e = element{kind: classElement, text: string(char)}
+			var err error
+			if e.class, err = alphabet.ParseClassExpr(string(char)); err != nil {
+				return nil, err
+			}
+		default:
+			segment := a.Segment(s[i:])[0]
+			if segment.Letter == nil {
+				return nil, fmt.Errorf("unknown letter %q", segment.Text)
+			}
+			size = len(segment.Text)
+			e = element{kind: letterElement, text: segment.Text, letter: segment.Letter}
+		}
+		e.optional = optional
+		elements = append(elements, e)
+		i += size
+	}
+	if optional {
+		return nil, fmt.Errorf("missing ')'")
+	}
+	return elements, nil
+}
+
+// cut is strings.Cut, ignoring separators inside braces and brackets.
+func cut(s, separator string) (before, after string, found bool) {
+	if i := index(s, separator); i >= 0 {
+		return s[:i], s[i+len(separator):], true
+	}
+	return s, "", false
+}
+
+// split is strings.Split, ignoring separators inside braces and brackets.
+func split(s, separator string) []string {
+	parts := []string{}
+	for {
+		i := index(s, separator)
+		if i < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:i])
+		s = s[i+len(separator):]
+	}
+}
+
+// index is strings.Index, ignoring matches inside braces and brackets.
+func index(s, substr string) int {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		}
+		if depth == 0 && strings.HasPrefix(s[i:], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// matches returns true if the element matches the Letter.
+func (e element) matches(a alphabet.Alphabet, l alphabet.Letter) bool {
+	switch e.kind {
+	case letterElement:
+		return l.Lower() == e.letter.Lower()
+	case classElement, featureElement:
+		return e.class.Matches(a, l)
+	}
+	return false
+}
@@ -0,0 +1,265 @@
+// Package soundchange applies ordered sound changes, written in a text rule
+// format like "p > f / V_V", to words written in an Alphabet.
+package soundchange
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Applier applies Rules in order.
+type Applier struct {
+	alphabet alphabet.Alphabet
+	rules    []Rule
+}
+
+// Step records one Rule that changed a word.
+type Step struct {
+	Rule          Rule
+	Before, After string
+}
+
+func (s Step) String() string {
+	return fmt.Sprintf("%s: %s → %s", s.Rule, s.Before, s.After)
+}
+
+// New makes an Applier of Rules parsed for the given Alphabet.
+func New(a alphabet.Alphabet, rules ...Rule) *Applier {
+	return &Applier{alphabet: a, rules: rules}
+}
+
+// Parse reads one Rule per line. Blank lines are skipped and "//" starts a
+// comment. Errors are reported as an *Error carrying the line of the Rule.
+func Parse(a alphabet.Alphabet, r io.Reader) (*Applier, error) {
+	applier := New(a)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "//")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		rule, err := ParseRule(a, text)
+		var ruleError *Error
+		if errors.As(err, &ruleError) {
+			ruleError.Line = line
+		}
+		if err != nil {
+			return nil, err
+		}
+		applier.rules = append(applier.rules, rule)
+	}
+	return applier, scanner.Err()
+}
+
+// Rules returns the Rules in the order they are applied.
+func (s *Applier) Rules() []Rule { return s.rules }
+
+// Apply applies every Rule, including optional ones, to a word and returns the
+// result in lower case along with a Step for each Rule that changed it.
+func (s *Applier) Apply(word string) (string, []Step, error) {
+	letters, err := s.tokenize(word)
+	if err != nil {
+		return "", nil, err
+	}
+	letters, steps := s.ApplyLetters(letters)
+	return spell(letters), steps, nil
+}
+
+// ApplyLetters is like Apply, but works on a word that is already split into
+// Letters of the Alphabet.
+func (s *Applier) ApplyLetters(letters []alphabet.Letter) ([]alphabet.Letter, []Step) {
+	steps := []Step{}
+	for _, rule := range s.rules {
+		changed := rule.apply(s.alphabet, letters)
+		if before, after := spell(letters), spell(changed); before != after {
+			steps = append(steps, Step{Rule: rule, Before: before, After: after})
+		}
+		letters = changed
+	}
+	return letters, steps
+}
+
+// Variants returns every result of applying the Rules to a word, with each
+// optional Rule either applied or not, in the order they were first found.
+func (s *Applier) Variants(word string) ([]string, error) {
+	letters, err := s.tokenize(word)
+	if err != nil {
+		return nil, err
+	}
+	words := [][]alphabet.Letter{letters}
+	for _, rule := range s.rules {
+		next := [][]alphabet.Letter{}
+		seen := map[string]bool{}
+		add := func(letters []alphabet.Letter) {
+			if !seen[spell(letters)] {
+				seen[spell(letters)] = true
+				next = append(next, letters)
+			}
+		}
+		for _, letters := range words {
+			if rule.Optional {
+				add(letters)
+			}
+			add(rule.apply(s.alphabet, letters))
+		}
+		words = next
+	}
+
+	variants := make([]string, len(words))
+	for i, letters := range words {
+		variants[i] = spell(letters)
+	}
+	return variants, nil
+}
+
+func (s *Applier) tokenize(word string) ([]alphabet.Letter, error) {
+	letters, err := s.alphabet.Tokenize(word)
+	var ambiguous *alphabet.AmbiguousError
+	if err != nil && !errors.As(err, &ambiguous) {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// spell writes Letters in lower case.
+func spell(letters []alphabet.Letter) string {
+	var b strings.Builder
+	for _, letter := range letters {
+		b.WriteString(letter.Lower())
+	}
+	return b.String()
+}
+
+// apply applies the Rule once to every place it matches, from left to right.
+// Environments are checked against the word as it was before the Rule.
+func (r Rule) apply(a alphabet.Alphabet, letters []alphabet.Letter) []alphabet.Letter {
+	changed := make([]alphabet.Letter, 0, len(letters))
+	for i := 0; i <= len(letters); {
+		n, ok := 0, false
+		for t, target := range r.targets {
+			if !r.matches(a, target, letters, i) {
+				continue
+			}
+			replacement := r.replacements[0]
+			if len(r.replacements) > 1 {
+				replacement = r.replacements[t]
+			}
+			changed = append(changed, r.replace(a, target, replacement, letters[i:i+len(target)])...)
+			n, ok = len(target), true
+			break
+		}
+		// insertions still copy the Letter they were inserted before
+		if !ok || n == 0 {
+			if i < len(letters) {
+				changed = append(changed, letters[i])
+			}
+			n = 1
+		}
+		i += n
+	}
+	return changed
+}
+
+// matches returns true if the target matches at i in an allowed context.
+func (r Rule) matches(a alphabet.Alphabet, target []element, letters []alphabet.Letter, i int) bool {
+	end := i + len(target)
+	if end > len(letters) {
+		return false
+	}
+	for j, e := range target {
+		if !e.matches(a, letters[i+j]) {
+			return false
+		}
+	}
+
+	inContext := func(contexts []context) bool {
+		for _, c := range contexts {
+			if matchBefore(a, c.before, letters, i-1) && matchAfter(a, c.after, letters, end) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(r.environments) > 0 && !inContext(r.environments) {
+		return false
+	}
+	return !inContext(r.exceptions)
+}
+
+// matchAfter matches elements forwards from the Letter at i.
+func matchAfter(a alphabet.Alphabet, elements []element, letters []alphabet.Letter, i int) bool {
+	if len(elements) == 0 {
+		return true
+	}
+	e, rest := elements[0], elements[1:]
+	if e.optional && matchAfter(a, rest, letters, i) {
+		return true
+	}
+	if e.kind == boundaryElement {
+		return i == len(letters) && matchAfter(a, rest, letters, i)
+	}
+	return i < len(letters) && e.matches(a, letters[i]) && matchAfter(a, rest, letters, i+1)
+}
+
+// matchBefore matches elements backwards from the Letter at i.
+func matchBefore(a alphabet.Alphabet, elements []element, letters []alphabet.Letter, i int) bool {
+	if len(elements) == 0 {
+		return true
+	}
+	e, rest := elements[len(elements)-1], elements[:len(elements)-1]
+	if e.optional && matchBefore(a, rest, letters, i) {
+		return true
+	}
+	if e.kind == boundaryElement {
+		return i == -1 && matchBefore(a, rest, letters, i)
+	}
+	return i >= 0 && e.matches(a, letters[i]) && matchBefore(a, rest, letters, i-1)
+}
+
+// replace returns the Letters a matched target becomes.
+func (r Rule) replace(a alphabet.Alphabet, target, replacement []element, matched []alphabet.Letter) []alphabet.Letter {
+	letters := make([]alphabet.Letter, 0, len(replacement))
+	for j, e := range replacement {
+		switch e.kind {
+		case letterElement:
+			letters = append(letters, e.letter)
+		case featureElement:
+			if modified, ok := a.Modify(matched[j], e.features); ok {
+				letters = append(letters, modified)
+			} else {
+				letters = append(letters, matched[j])
+			}
+		case classElement:
+			letters = append(letters, correspond(a, target[j], e, matched[j]))
+		}
+	}
+	return letters
+}
+
+// correspond returns the Letter of a replacement Class at the same position as
+// the matched Letter has in the target's Class, or the matched Letter if there
+// is none.
+func correspond(a alphabet.Alphabet, target, replacement element, matched alphabet.Letter) alphabet.Letter {
+	if target.kind != classElement && target.kind != featureElement {
+		return matched
+	}
+	from, err := a.Query(target.class.String())
+	if err != nil {
+		return matched
+	}
+	to, err := a.Query(replacement.class.String())
+	if err != nil {
+		return matched
+	}
+	for i, letter := range from.ToSlice() {
+		if letter.Lower() == matched.Lower() && i < to.Len() {
+			return to.ToSlice()[i]
+		}
+	}
+	return matched
+}
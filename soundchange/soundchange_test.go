@@ -0,0 +1,139 @@
+package soundchange
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+func testAlphabet() alphabet.Alphabet {
+	letter := func(lower, ipa string, classes ...alphabet.Class) alphabet.Letter {
+		return alphabet.WithIPA(alphabet.NewLetter(strings.ToUpper(lower), lower, classes...), ipa)
+	}
+	return alphabet.New([]alphabet.Letter{
+		letter("a", "a", 'V'),
+		letter("e", "e", 'V'),
+		letter("i", "i", 'V'),
+		letter("o", "o", 'V'),
+		letter("u", "u", 'V'),
+		letter("p", "p", 'C', 'P'),
+		letter("b", "b", 'C', 'P'),
+		letter("t", "t", 'C', 'P'),
+		letter("d", "d", 'C', 'P'),
+		letter("k", "k", 'C', 'P'),
+		letter("g", "ɡ", 'C', 'P'),
+		letter("f", "f", 'C', 'F'),
+		letter("v", "v", 'C', 'F'),
+		letter("s", "s", 'C', 'F'),
+		letter("z", "z", 'C', 'F'),
+		letter("x", "x", 'C', 'F'),
+		letter("h", "h", 'C', 'F'),
+		letter("m", "m", 'C', 'N'),
+		letter("n", "n", 'C', 'N'),
+		letter("r", "r", 'C'),
+		letter("sh", "ʃ", 'C', 'F'),
+	}, alphabet.WithShorthand('P', "stop"), alphabet.WithShorthand('F', "fricative"), alphabet.WithShorthand('N', "nasal"))
+}
+
+func TestApply(t *testing.T) {
+	a := testAlphabet()
+	for _, testCase := range []struct {
+		Rule  string
+		Words map[string]string
+	}{
+		{"p > f / V_V", map[string]string{"apa": "afa", "pap": "pap", "apapa": "afafa"}},
+		{"V > 0 / _#", map[string]string{"kata": "kat", "ka": "k", "tak": "tak"}},
+		{"p, t, k > b, d, g / V_V", map[string]string{"apotuka": "aboduga"}},
+		{"P > F / V_V", map[string]string{"apotuka": "afosuxa"}},
+		{"[-voice -continuant] > [+voice] / V_V", map[string]string{"apotuka": "aboduga"}},
+		{"{stop & [-voice]} > h / _#", map[string]string{"kat": "kah", "kad": "kad"}},
+		{"0 > e / #_sP", map[string]string{"spa": "espa", "sa": "sa"}},
+		{"s > sh / _i, i_", map[string]string{"sisa": "shisha", "sasa": "sasa"}},
+		{"k > x / V_ ! _i, _e", map[string]string{"aka": "axa", "aki": "aki", "ake": "ake"}},
+		{"n > m / _(V)p", map[string]string{"anpa": "ampa", "napa": "mapa", "nata": "nata"}},
+		{"V > 0 / C_C#", map[string]string{"patak": "patk"}},
+		{"t > 0 / V_V", map[string]string{"atata": "aaa"}},
+		{"Ap > b", map[string]string{"Apa": "ba"}},
+	} {
+		rule, err := ParseRule(a, testCase.Rule)
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		applier := New(a, rule)
+		for word, expected := range testCase.Words {
+			if result, _, err := applier.Apply(word); err != nil || result != expected {
+				t.Logf("Expected %q to turn %q into %q; got %q (%v)\n", testCase.Rule, word, expected, result, err)
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	a := testAlphabet()
+	applier, err := Parse(a, strings.NewReader(`
+// lenition, then loss of final vowels
+p, t, k > f, s, x / V_V
+V > 0 / C_#   // apocope
+? s > h / #_
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applier.Rules()) != 3 || !applier.Rules()[2].Optional {
+		t.Logf("Expected 3 rules, the last optional; got %v\n", applier.Rules())
+		t.Fail()
+	}
+
+	result, steps, err := applier.Apply("Sapata")
+	if err != nil || result != "hafas" {
+		t.Logf("Expected \"Sapata\" to become \"hafas\"; got %q (%v)\n", result, err)
+		t.Fail()
+	}
+	trace := make([]string, len(steps))
+	for i, step := range steps {
+		trace[i] = step.String()
+	}
+	expected := []string{
+		"p, t, k > f, s, x / V_V: sapata → safasa",
+		"V > 0 / C_#: safasa → safas",
+		"? s > h / #_: safas → hafas",
+	}
+	if !slices.Equal(trace, expected) {
+		t.Logf("Expected trace %q; got %q\n", expected, trace)
+		t.Fail()
+	}
+
+	variants, err := applier.Variants("sapata")
+	if err != nil || !slices.Equal(variants, []string{"safas", "hafas"}) {
+		t.Logf("Expected variants \"safas\" and \"hafas\"; got %v (%v)\n", variants, err)
+		t.Fail()
+	}
+
+	for _, text := range []string{
+		"p f / V_V",
+		"p > / V_V",
+		"p > f / VV",
+		"p, t, k > f, s",
+		"p > q",
+		"p > f / _(V",
+		"# > f",
+		"0 > P",
+		"p > {stop & } / _",
+	} {
+		if _, err := ParseRule(a, text); err == nil {
+			t.Logf("Expected an error parsing %q\n", text)
+			t.Fail()
+		}
+	}
+
+	_, err = Parse(a, strings.NewReader("p > f\n\np > q\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Logf("Expected an error on line 3; got %v\n", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,236 @@
+// Package syllabify splits words written in an Alphabet into syllables.
+package syllabify
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/phonotactics"
+)
+
+// Syllable is a nucleus along with the Letters before and after it.
+type Syllable struct {
+	Letters []alphabet.Letter
+	// Nucleus is the index of the nucleus in Letters.
+	Nucleus int
+	// Text and Offset locate the Syllable in the word it was split from, when
+	// it was split by SyllabifyWord.
+	Text   string
+	Offset int
+}
+
+// Onset returns the Letters before the nucleus.
+func (s Syllable) Onset() []alphabet.Letter { return s.Letters[:s.Nucleus] }
+
+// Coda returns the Letters after the nucleus.
+func (s Syllable) Coda() []alphabet.Letter { return s.Letters[s.Nucleus+1:] }
+
+// Rhyme returns the nucleus and the coda.
+func (s Syllable) Rhyme() []alphabet.Letter { return s.Letters[s.Nucleus:] }
+
+// Roles returns the syllable Position of each Letter, as used by
+// alphabet.CheckPositions.
+func (s Syllable) Roles() []alphabet.Position {
+	roles := make([]alphabet.Position, len(s.Letters))
+	for i := range roles {
+		switch {
+		case i < s.Nucleus:
+			roles[i] = alphabet.Onset
+		case i == s.Nucleus:
+			roles[i] = alphabet.Nucleus
+		default:
+			roles[i] = alphabet.Coda
+		}
+	}
+	return roles
+}
+
+func (s Syllable) String() string {
+	if s.Text != "" {
+		return s.Text
+	}
+	var b strings.Builder
+	for _, letter := range s.Letters {
+		b.WriteString(letter.Lower())
+	}
+	return b.String()
+}
+
+// Principles configure how a Syllabifier splits the consonants between two
+// nuclei. Clusters are spelled in Letters of the Alphabet.
+type Principles struct {
+	// MaximalOnset gives as many consonants as possible to the onset of the
+	// following syllable. Otherwise only the last consonant is given to it, if
+	// the coda allows the rest.
+	MaximalOnset bool
+	// Sonority returns the sonority of a Letter. If set, onsets must rise and
+	// codas must fall in sonority towards the nucleus.
+	Sonority func(alphabet.Letter) int
+	// Onsets lists the onset clusters of two or more Letters allowed. If
+	// empty, any onset cluster is.
+	Onsets []string
+	// Codas lists the coda clusters of two or more Letters allowed. If empty,
+	// any coda cluster is.
+	Codas []string
+	// Nucleus is the Class of Letters that are nuclei, besides those with the
+	// Syllabic feature. If zero, it is 'V'.
+	Nucleus alphabet.Class
+}
+
+// Syllabifier splits words into Syllables.
+type Syllabifier struct {
+	alphabet   alphabet.Alphabet
+	principles Principles
+	onsets     phonotactics.Clusters
+	codas      phonotactics.Clusters
+}
+
+// Error is returned by SyllabifyWord for a word with no nucleus.
+type Error struct {
+	Word string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("syllabify: no nucleus in %q", e.Word)
+}
+
+// New makes a Syllabifier for the given Alphabet. Nuclei are Letters for which
+// alphabet.IsNucleus is true with the Nucleus Class.
+func New(a alphabet.Alphabet, principles Principles) (*Syllabifier, error) {
+	if principles.Nucleus == 0 {
+		principles.Nucleus = 'V'
+	}
+	s := &Syllabifier{alphabet: a, principles: principles}
+	var err error
+	if s.onsets, err = phonotactics.ParseClusters(a, principles.Onsets); err != nil {
+		return nil, fmt.Errorf("syllabify: onsets: %w", err)
+	}
+	if s.codas, err = phonotactics.ParseClusters(a, principles.Codas); err != nil {
+		return nil, fmt.Errorf("syllabify: codas: %w", err)
+	}
+	return s, nil
+}
+
+// IsNucleus returns true if the Letter can be the nucleus of a Syllable.
+func (s *Syllabifier) IsNucleus(l alphabet.Letter) bool {
+	return alphabet.IsNucleus(s.alphabet, l, s.principles.Nucleus)
+}
+
+// Syllabify splits the Letters of a word into Syllables. Each nucleus starts a
+// new Syllable, consonants before the first nucleus belong to its onset and
+// consonants after the last belong to its coda. It returns nil if there is no
+// nucleus.
+func (s *Syllabifier) Syllabify(letters []alphabet.Letter) []Syllable {
+	nuclei := []int{}
+	for i, letter := range letters {
+		if s.IsNucleus(letter) {
+			nuclei = append(nuclei, i)
+		}
+	}
+	if len(nuclei) == 0 {
+		return nil
+	}
+
+	// starts holds the index of the first Letter of each Syllable
+	starts := make([]int, len(nuclei))
+	for i := 1; i < len(nuclei); i++ {
+		starts[i] = nuclei[i-1] + 1 + s.split(letters[nuclei[i-1]+1:nuclei[i]])
+	}
+
+	syllables := make([]Syllable, len(nuclei))
+	for i, start := range starts {
+		end := len(letters)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		syllables[i] = Syllable{Letters: letters[start:end:end], Nucleus: nuclei[i] - start}
+	}
+	return syllables
+}
+
+// SyllabifyWord tokenizes a word and splits it into Syllables that keep their
+// original spelling. It returns an *Error if the word has no nucleus.
+func (s *Syllabifier) SyllabifyWord(word string) ([]Syllable, error) {
+	letters, err := phonotactics.Tokenize(s.alphabet, word)
+	if err != nil {
+		return nil, err
+	}
+	syllables := s.Syllabify(letters)
+	if syllables == nil {
+		return nil, &Error{Word: word}
+	}
+
+	segments := s.alphabet.Segment(word)
+	i := 0
+	for j := range syllables {
+		start, end := segments[i], segments[i+len(syllables[j].Letters)-1]
+		syllables[j].Offset = start.Offset
+		syllables[j].Text = word[start.Offset : end.Offset+len(end.Text)]
+		i += len(syllables[j].Letters)
+	}
+	return syllables, nil
+}
+
+// Hyphenate joins the Text of Syllables with a separator, such as "-" or "·".
+func Hyphenate(syllables []Syllable, separator string) string {
+	texts := make([]string, len(syllables))
+	for i, syllable := range syllables {
+		texts[i] = syllable.String()
+	}
+	return strings.Join(texts, separator)
+}
+
+// split returns how many consonants between two nuclei belong to the coda of
+// the first, giving the rest to the onset of the second.
+func (s *Syllabifier) split(cluster []alphabet.Letter) int {
+	n := len(cluster)
+	if n == 0 {
+		return 0
+	}
+
+	legal := func(coda int) bool {
+		return s.legalCoda(cluster[:coda]) && s.legalOnset(cluster[coda:])
+	}
+	if s.principles.MaximalOnset {
+		for coda := 0; coda <= n; coda++ {
+			if legal(coda) {
+				return coda
+			}
+		}
+	} else if legal(n - 1) {
+		return n - 1
+	}
+
+	// no split satisfies both sides, so favour the onset
+	for coda := 0; coda <= n; coda++ {
+		if s.legalOnset(cluster[coda:]) {
+			return coda
+		}
+	}
+	return n
+}
+
+func (s *Syllabifier) legalOnset(onset []alphabet.Letter) bool {
+	for i, letter := range onset {
+		if !letter.Positions().Allows(alphabet.Onset) {
+			return false
+		}
+		if s.principles.Sonority != nil && i > 0 && s.principles.Sonority(onset[i-1]) >= s.principles.Sonority(letter) {
+			return false
+		}
+	}
+	return s.onsets.Allows(onset)
+}
+
+func (s *Syllabifier) legalCoda(coda []alphabet.Letter) bool {
+	for i, letter := range coda {
+		if !letter.Positions().Allows(alphabet.Coda) {
+			return false
+		}
+		if s.principles.Sonority != nil && i > 0 && s.principles.Sonority(coda[i-1]) <= s.principles.Sonority(letter) {
+			return false
+		}
+	}
+	return s.codas.Allows(coda)
+}
@@ -0,0 +1,107 @@
+package syllabify
+
+import (
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+func testAlphabet() alphabet.Alphabet {
+	return alphabet.New([]alphabet.Letter{
+		alphabet.NewLetter("A", "a", 'V'),
+		alphabet.NewLetter("E", "e", 'V'),
+		alphabet.NewLetter("I", "i", 'V'),
+		alphabet.NewLetter("K", "k", 'C', 'P'),
+		alphabet.NewLetter("L", "l", 'C', 'L'),
+		alphabet.NewLetter("M", "m", 'C', 'N'),
+		alphabet.WithPositions(alphabet.NewLetter("NG", "ng", 'C', 'N'), alphabet.Coda),
+		alphabet.NewLetter("P", "p", 'C', 'P'),
+		alphabet.NewLetter("R", "r", 'C', 'L'),
+		alphabet.NewLetter("S", "s", 'C', 'F'),
+		alphabet.NewLetter("T", "t", 'C', 'P'),
+	})
+}
+
+func TestSyllabify(t *testing.T) {
+	a := testAlphabet()
+	sonority := func(l alphabet.Letter) int {
+		for i, class := range []alphabet.Class{'P', 'F', 'N', 'L'} {
+			if a.HasClass(l, class) {
+				return i
+			}
+		}
+		return 4
+	}
+
+	for _, testCase := range []struct {
+		Name       string
+		Principles Principles
+		Words      map[string]string
+	}{
+		{"maximal onset", Principles{MaximalOnset: true}, map[string]string{
+			"Patra":   "Pa-tra",
+			"astra":   "a-stra",
+			"pae":     "pa-e",
+			"kalampe": "ka-la-mpe",
+			"tangi":   "tang-i",
+			"tast":    "tast",
+		}},
+		{"sonority", Principles{MaximalOnset: true, Sonority: sonority}, map[string]string{
+			"patra":   "pa-tra",
+			"astra":   "as-tra",
+			"kalampe": "ka-lam-pe",
+			"almta":   "alm-ta",
+		}},
+		{"allowed clusters", Principles{MaximalOnset: true, Onsets: []string{"tr", "pl"}, Codas: []string{"mp"}}, map[string]string{
+			"patra":   "pa-tra",
+			"astra":   "as-tra",
+			"kampla":  "kam-pla",
+			"kampsa":  "kamp-sa",
+			"kalampe": "ka-lam-pe",
+		}},
+		{"single onset", Principles{}, map[string]string{
+			"patra": "pat-ra",
+			"astra": "ast-ra",
+		}},
+		{"liquid nuclei", Principles{MaximalOnset: true, Nucleus: 'L'}, map[string]string{
+			"krpl": "kr-pl",
+			"trl":  "tr-l",
+		}},
+	} {
+		s, err := New(a, testCase.Principles)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for word, expected := range testCase.Words {
+			syllables, err := s.SyllabifyWord(word)
+			if err != nil {
+				t.Log(testCase.Name, err)
+				t.Fail()
+				continue
+			}
+			if hyphenated := Hyphenate(syllables, "-"); hyphenated != expected {
+				t.Logf("Expected %s to split %q as %q; got %q\n", testCase.Name, word, expected, hyphenated)
+				t.Fail()
+			}
+		}
+	}
+
+	s, _ := New(a, Principles{MaximalOnset: true})
+	syllables, _ := s.SyllabifyWord("Strang")
+	if len(syllables) != 1 || len(syllables[0].Onset()) != 3 || len(syllables[0].Coda()) != 1 || syllables[0].Offset != 0 {
+		t.Logf("Expected \"Strang\" to be one syllable with onset \"str\" and coda \"ng\"; got %v\n", syllables)
+		t.Fail()
+	}
+	if _, err := s.SyllabifyWord("pst"); err == nil {
+		t.Log("Expected an error for a word with no nucleus")
+		t.Fail()
+	}
+	if _, err := New(a, Principles{Onsets: []string{"t"}}); err == nil {
+		t.Log("Expected an error for a single letter onset cluster")
+		t.Fail()
+	}
+	if _, err := New(a, Principles{Onsets: []string{"STR"}}); err != nil {
+		t.Logf("Expected onset clusters to ignore case; got %v\n", err)
+		t.Fail()
+	}
+}
@@ -0,0 +1,62 @@
+package tone
+
+import "github.com/jack-reeser/conlang/morph"
+
+// morpheme is a morph.Morpheme with lexical tone.
+type morpheme struct {
+	morph.Morpheme
+	tier Tier
+}
+
+// Attach returns the Morpheme with lexical tone, one Tone per syllable, such
+// as a stem from morph.NewStem or an affix from morph.NewMorpheme. Use an
+// empty Tone for a toneless syllable.
+//
+// Combining a toned Morpheme keeps the tones of both sides, with a morpheme
+// boundary between them. The Combine method of a Morpheme without tone drops
+// them, so combine with a toned Morpheme as the receiver or with Combine.
+func Attach(m morph.Morpheme, tones ...Tone) morph.Morpheme {
+	return morpheme{Morpheme: unwrap(m), tier: NewTier(tones...)}
+}
+
+// Of returns the tones of a Morpheme, or nil if it has none.
+func Of(m morph.Morpheme) Tier {
+	if toned, ok := m.(morpheme); ok {
+		return toned.tier
+	}
+	return nil
+}
+
+func (m morpheme) Combine(other morph.Morpheme) morph.Morpheme { return Combine(m, other) }
+
+// Combine combines two Morphemes like morph.Morpheme.Combine, joining their
+// tones in the order the Morphemes are written.
+func Combine(a, b morph.Morpheme) morph.Morpheme {
+	combined := unwrap(a).Combine(unwrap(b))
+	first, second := Of(a), Of(b)
+	if !precedes(a, b) {
+		first, second = second, first
+	}
+	return morpheme{Morpheme: combined, tier: Join(MorphemeBoundary, first, second)}
+}
+
+func unwrap(m morph.Morpheme) morph.Morpheme {
+	if toned, ok := m.(morpheme); ok {
+		return toned.Morpheme
+	}
+	return m
+}
+
+// precedes returns true if a is written before b when they are combined, as
+// described by morph.Morpheme.Combine.
+func precedes(a, b morph.Morpheme) bool {
+	switch {
+	case a.IsFree() && !b.IsFree():
+		return !b.IsPrefix()
+	case !a.IsFree() && b.IsFree():
+		return a.IsPrefix()
+	case !a.IsFree() && a.IsPrefix() != b.IsPrefix():
+		return a.IsPrefix()
+	}
+	return true
+}
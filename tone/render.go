@@ -0,0 +1,99 @@
+package tone
+
+import (
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/syllabify"
+)
+
+// Combining diacritics commonly used to mark tone.
+const (
+	Acute      = "\u0301"
+	Grave      = "\u0300"
+	Macron     = "\u0304"
+	Caron      = "\u030C"
+	Circumflex = "\u0302"
+)
+
+// Mark returns the default diacritic of a Tone: an acute for high level
+// tones, a macron for mid, a grave for low, a caron for rising and dipping
+// contours and a circumflex for falling and peaking ones. Toneless syllables
+// have no mark.
+func Mark(t Tone) string {
+	if len(t) == 0 {
+		return ""
+	}
+	first, last := t[0], t[len(t)-1]
+	switch {
+	case !t.IsContour() && first >= 4:
+		return Acute
+	case !t.IsContour() && first == 3:
+		return Macron
+	case !t.IsContour():
+		return Grave
+	case last > first, last == first && t[1] < first:
+		return Caron
+	}
+	return Circumflex
+}
+
+// Orthography spells the tones of syllables.
+type Orthography struct {
+	// Marks maps Tones, written in Chao numbers, to the diacritic written on
+	// the nucleus. Tones that are not in Marks take their default Mark.
+	Marks map[string]string
+	// Numbers maps Tones to the number written after the syllable, such as
+	// "3" for "214" in Pinyin. Tones that are not in Numbers are written in
+	// Chao numbers.
+	Numbers map[string]string
+}
+
+func (o Orthography) mark(t Tone) string {
+	if mark, ok := o.Marks[t.String()]; ok {
+		return mark
+	}
+	return Mark(t)
+}
+
+func (o Orthography) number(t Tone) string {
+	if number, ok := o.Numbers[t.String()]; ok {
+		return number
+	}
+	return t.String()
+}
+
+// Diacritics writes syllables with the mark of each Tone on the nucleus. A
+// marked nucleus is written as the Letter of the Alphabet spelled by the
+// nucleus and the mark, or with the mark appended if there is none.
+// Syllables keep their original spelling where they have one.
+func (o Orthography) Diacritics(a alphabet.Alphabet, syllables []syllabify.Syllable, tier Tier) string {
+	var b strings.Builder
+	for i, syllable := range syllables {
+		mark := ""
+		if i < len(tier) {
+			mark = o.mark(tier[i].Tone)
+		}
+		for j, segment := range a.Segment(syllable.String()) {
+			if j != syllable.Nucleus || mark == "" || segment.Letter == nil {
+				b.WriteString(segment.Text)
+				continue
+			}
+			b.WriteString(alphabet.Mark(a, segment, mark))
+		}
+	}
+	return b.String()
+}
+
+// Numbered writes syllables each followed by the number of its Tone, with a
+// separator between them.
+func (o Orthography) Numbered(syllables []syllabify.Syllable, tier Tier, separator string) string {
+	parts := make([]string, len(syllables))
+	for i, syllable := range syllables {
+		parts[i] = syllable.String()
+		if i < len(tier) {
+			parts[i] += o.number(tier[i].Tone)
+		}
+	}
+	return strings.Join(parts, separator)
+}
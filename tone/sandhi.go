@@ -0,0 +1,190 @@
+package tone
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rule is a tone sandhi rule, written as
+//
+//	target > replacement / environment
+//
+// such as "214 > 35 / _ 214", where the target and replacement are Tones and
+// the optional environment is a list of contexts separated by commas. In a
+// context, "_" stands for the target, Tones are separated by spaces, "*" is any
+// Tone, "+" is a morpheme boundary and "#" is a word boundary or the edge of
+// the Tier. A Tone with no boundary before it may be across any boundary, so
+// "_ 214" applies within and across words while "_ + 214" applies only across
+// a morpheme boundary.
+type Rule struct {
+	// Text is the Rule as written.
+	Text        string
+	Target      Tone
+	Replacement Tone
+
+	environments []sandhiContext
+}
+
+func (r Rule) String() string { return r.Text }
+
+// sandhiElement is a Tone, any Tone, or a Boundary.
+type sandhiElement struct {
+	tone     Tone
+	any      bool
+	boundary Boundary
+}
+
+type sandhiContext struct {
+	before, after []sandhiElement
+}
+
+// Error reports a Rule that cannot be parsed.
+type Error struct {
+	Rule    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("tone: rule %q: %s", e.Rule, e.Message)
+}
+
+// ParseRule parses a sandhi Rule.
+func ParseRule(text string) (Rule, error) {
+	rule := Rule{Text: strings.TrimSpace(text)}
+	fail := func(format string, args ...any) (Rule, error) {
+		return Rule{}, &Error{Rule: rule.Text, Message: fmt.Sprintf(format, args...)}
+	}
+
+	s, environment, hasEnvironment := strings.Cut(rule.Text, "/")
+	target, replacement, ok := strings.Cut(s, ">")
+	if !ok {
+		return fail("missing '>'")
+	}
+	var err error
+	if rule.Target, err = ParseTone(target); err != nil || len(rule.Target) == 0 {
+		return fail("invalid target %q", strings.TrimSpace(target))
+	}
+	if rule.Replacement, err = ParseTone(replacement); err != nil {
+		return fail("invalid replacement %q", strings.TrimSpace(replacement))
+	}
+	if !hasEnvironment {
+		return rule, nil
+	}
+	for _, c := range strings.Split(environment, ",") {
+		before, after, ok := strings.Cut(c, "_")
+		if !ok {
+			return fail("missing '_' in %q", strings.TrimSpace(c))
+		}
+		var parsed sandhiContext
+		if parsed.before, err = parseElements(before); err != nil {
+			return fail("%s", err)
+		}
+		if parsed.after, err = parseElements(after); err != nil {
+			return fail("%s", err)
+		}
+		rule.environments = append(rule.environments, parsed)
+	}
+	return rule, nil
+}
+
+// MustParseRule is like ParseRule but panics if the Rule cannot be parsed.
+func MustParseRule(text string) Rule {
+	rule, err := ParseRule(text)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+func parseElements(s string) ([]sandhiElement, error) {
+	s = strings.ReplaceAll(strings.ReplaceAll(s, "+", " + "), "#", " # ")
+	elements := []sandhiElement{}
+	for _, field := range strings.Fields(s) {
+		switch field {
+		case "+":
+			elements = append(elements, sandhiElement{boundary: MorphemeBoundary})
+		case "#":
+			elements = append(elements, sandhiElement{boundary: WordBoundary})
+		case "*":
+			elements = append(elements, sandhiElement{any: true})
+		default:
+			t, err := ParseTone(field)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, sandhiElement{tone: t})
+		}
+	}
+	return elements, nil
+}
+
+// Apply applies Rules in order to a Tier and returns the result. Each Rule
+// applies at once to every syllable it matches, checking its environment
+// against the Tier as it was before the Rule.
+func Apply(tier Tier, rules ...Rule) Tier {
+	for _, rule := range rules {
+		changed := append(Tier{}, tier...)
+		for i, unit := range tier {
+			if unit.Tone.Equal(rule.Target) && rule.matches(tier, i) {
+				changed[i].Tone = rule.Replacement
+			}
+		}
+		tier = changed
+	}
+	return tier
+}
+
+// matches returns true if the Rule's environment allows it at i.
+func (r Rule) matches(tier Tier, i int) bool {
+	if len(r.environments) == 0 {
+		return true
+	}
+	for _, c := range r.environments {
+		if matchBefore(c.before, tier, i) && matchAfter(c.after, tier, i+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchBefore matches elements backwards from the gap before the unit at k.
+func matchBefore(elements []sandhiElement, tier Tier, k int) bool {
+	for j := len(elements) - 1; j >= 0; j-- {
+		e := elements[j]
+		if e.tone == nil && !e.any {
+			if !(k == 0 && e.boundary == WordBoundary) && (k == 0 || tier[k].Boundary != e.boundary) {
+				return false
+			}
+			continue
+		}
+		if k == 0 || !e.matches(tier[k-1].Tone) {
+			return false
+		}
+		k--
+	}
+	return true
+}
+
+// matchAfter matches elements forwards from the gap before the unit at k.
+func matchAfter(elements []sandhiElement, tier Tier, k int) bool {
+	for _, e := range elements {
+		if e.tone == nil && !e.any {
+			if !(k == len(tier) && e.boundary == WordBoundary) && (k == len(tier) || tier[k].Boundary != e.boundary) {
+				return false
+			}
+			continue
+		}
+		if k == len(tier) || !e.matches(tier[k].Tone) {
+			return false
+		}
+		k++
+	}
+	return true
+}
+
+func (e sandhiElement) matches(t Tone) bool {
+	if e.any {
+		return len(t) > 0
+	}
+	return t.Equal(e.tone)
+}
@@ -0,0 +1,155 @@
+// Package tone represents lexical tone on a tier of its own, parallel to the
+// syllables of a word: level and contour tones written in Chao tone numbers,
+// their spelling with diacritics or numbers, and tone sandhi.
+package tone
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Tone is the pitch of a syllable as a sequence of Chao levels from 1, the
+// lowest, to 5, the highest. A single level, or a repeated one like "55", is
+// a level tone; a sequence of different levels like "35" or "214" is a
+// contour. An empty Tone is toneless.
+type Tone []int
+
+// chaoLetters are the IPA tone letters of levels 1 to 5.
+var chaoLetters = []rune{'˩', '˨', '˧', '˦', '˥'}
+
+// ParseTone parses a Tone written in Chao numbers such as "35" or in IPA tone
+// letters such as "˧˥". An empty string is toneless.
+func ParseTone(s string) (Tone, error) {
+	t := Tone{}
+	for _, char := range strings.TrimSpace(s) {
+		level := 0
+		if char >= '1' && char <= '5' {
+			level = int(char - '0')
+		}
+		for i, letter := range chaoLetters {
+			if char == letter {
+				level = i + 1
+			}
+		}
+		if level == 0 {
+			return nil, fmt.Errorf("tone: invalid level %q in %q", char, s)
+		}
+		t = append(t, level)
+	}
+	if len(t) > 3 {
+		return nil, fmt.Errorf("tone: %q has more than 3 levels", s)
+	}
+	return t, nil
+}
+
+// MustParseTone is like ParseTone but panics if the Tone cannot be parsed.
+func MustParseTone(s string) Tone {
+	t, err := ParseTone(s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// String writes the Tone in Chao numbers.
+func (t Tone) String() string {
+	var b strings.Builder
+	for _, level := range t {
+		fmt.Fprint(&b, level)
+	}
+	return b.String()
+}
+
+// Letters writes the Tone in IPA tone letters. Levels outside 1 to 5 are
+// written as the nearest level.
+func (t Tone) Letters() string {
+	var b strings.Builder
+	for _, level := range t {
+		b.WriteRune(chaoLetters[min(max(level, 1), len(chaoLetters))-1])
+	}
+	return b.String()
+}
+
+// IsContour returns true if the pitch of the Tone changes.
+func (t Tone) IsContour() bool {
+	for _, level := range t {
+		if level != t[0] {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal returns true if both Tones have the same levels.
+func (t Tone) Equal(other Tone) bool { return t.String() == other.String() }
+
+// Boundary is the kind of boundary before a syllable.
+type Boundary int
+
+// Boundaries, from weakest to strongest.
+const (
+	None Boundary = iota
+	MorphemeBoundary
+	WordBoundary
+)
+
+// Unit is the Tone of one syllable and the Boundary before it.
+type Unit struct {
+	Tone     Tone
+	Boundary Boundary
+}
+
+// Tier is the tones of a sequence of syllables, one Unit per syllable.
+type Tier []Unit
+
+// NewTier makes the Tier of a single morpheme, one Tone per syllable.
+func NewTier(tones ...Tone) Tier {
+	tier := make(Tier, len(tones))
+	for i, t := range tones {
+		tier[i] = Unit{Tone: t}
+	}
+	return tier
+}
+
+// Tones returns the Tone of each syllable.
+func (t Tier) Tones() []Tone {
+	tones := make([]Tone, len(t))
+	for i, unit := range t {
+		tones[i] = unit.Tone
+	}
+	return tones
+}
+
+// Join joins Tiers with a Boundary between each of them, such as the words of
+// a phrase with WordBoundary.
+func Join(boundary Boundary, tiers ...Tier) Tier {
+	joined := Tier{}
+	for _, tier := range tiers {
+		tier = append(Tier{}, tier...)
+		if len(joined) > 0 && len(tier) > 0 && tier[0].Boundary < boundary {
+			tier[0].Boundary = boundary
+		}
+		joined = append(joined, tier...)
+	}
+	return joined
+}
+
+func (t Tier) String() string {
+	var b strings.Builder
+	for i, unit := range t {
+		switch {
+		case i == 0:
+		case unit.Boundary == WordBoundary:
+			b.WriteString(" # ")
+		case unit.Boundary == MorphemeBoundary:
+			b.WriteString(" + ")
+		default:
+			b.WriteString(" ")
+		}
+		if len(unit.Tone) == 0 {
+			b.WriteString("0")
+		}
+		b.WriteString(unit.Tone.String())
+	}
+	return b.String()
+}
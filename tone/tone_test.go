@@ -0,0 +1,159 @@
+package tone
+
+import (
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+	"github.com/jack-reeser/conlang/morph"
+	"github.com/jack-reeser/conlang/syllabify"
+)
+
+func TestParseTone(t *testing.T) {
+	for _, testCase := range []struct {
+		Input   string
+		Output  string
+		Letters string
+		Contour bool
+		Err     bool
+	}{
+		{"35", "35", "˧˥", true, false},
+		{"˨˩˦", "214", "˨˩˦", true, false},
+		{"55", "55", "˥˥", false, false},
+		{"1", "1", "˩", false, false},
+		{"", "", "", false, false},
+		{"6", "", "", false, true},
+		{"2143", "", "", false, true},
+	} {
+		tone, err := ParseTone(testCase.Input)
+		if (err != nil) != testCase.Err || err == nil && (tone.String() != testCase.Output || tone.Letters() != testCase.Letters || tone.IsContour() != testCase.Contour) {
+			t.Logf("Expected %q to parse as %q %q (contour %v); got %q %q (%v)\n", testCase.Input, testCase.Output, testCase.Letters, testCase.Contour, tone, tone.Letters(), err)
+			t.Fail()
+		}
+	}
+
+	if letters := (Tone{0, 7}).Letters(); letters != "˩˥" {
+		t.Logf("Expected levels out of range to be clamped; got %q\n", letters)
+		t.Fail()
+	}
+}
+
+func TestMark(t *testing.T) {
+	for _, testCase := range []struct {
+		Tone string
+		Mark string
+	}{
+		{"55", Acute},
+		{"4", Acute},
+		{"33", Macron},
+		{"11", Grave},
+		{"35", Caron},
+		{"214", Caron},
+		{"51", Circumflex},
+		{"242", Circumflex},
+		{"", ""},
+	} {
+		if mark := Mark(MustParseTone(testCase.Tone)); mark != testCase.Mark {
+			t.Logf("Expected %q to be marked %q; got %q\n", testCase.Tone, testCase.Mark, mark)
+			t.Fail()
+		}
+	}
+}
+
+func TestSandhi(t *testing.T) {
+	third := NewTier(MustParseTone("214"))
+	word := Join(MorphemeBoundary, third, third)
+	for _, testCase := range []struct {
+		Rules  []string
+		Tier   Tier
+		Output string
+	}{
+		{[]string{"214 > 35 / _ 214"}, word, "35 + 214"},
+		{[]string{"214 > 35 / _ 214"}, Join(WordBoundary, third, third), "35 # 214"},
+		{[]string{"214 > 35 / _ + 214"}, Join(WordBoundary, third, third), "214 # 214"},
+		{[]string{"214 > 35 / _ # 214"}, Join(WordBoundary, word, third), "214 + 35 # 214"},
+		{[]string{"214 > 21 / _ #"}, Join(WordBoundary, word, third), "214 + 21 # 21"},
+		{[]string{"214 > 35 / 214 + _"}, word, "214 + 35"},
+		{[]string{"214 > 35 / _ *"}, Join(MorphemeBoundary, third, NewTier(nil)), "214 + 0"},
+		{[]string{"214 > 35 / _ 214", "35 > 55 / # _"}, Join(WordBoundary, word, third), "55 + 35 # 214"},
+		{[]string{"214 > 35"}, third, "35"},
+	} {
+		rules := make([]Rule, len(testCase.Rules))
+		for i, text := range testCase.Rules {
+			rules[i] = MustParseRule(text)
+		}
+		if output := Apply(testCase.Tier, rules...).String(); output != testCase.Output {
+			t.Logf("Expected %v on %q to give %q; got %q\n", testCase.Rules, testCase.Tier, testCase.Output, output)
+			t.Fail()
+		}
+	}
+
+	for _, text := range []string{"214 35", "> 35", "214 > 6", "214 > 35 / 214", "214 > 35 / _ x"} {
+		if _, err := ParseRule(text); err == nil {
+			t.Logf("Expected an error for %q\n", text)
+			t.Fail()
+		}
+	}
+}
+
+func TestMorpheme(t *testing.T) {
+	high, low, rising := MustParseTone("55"), MustParseTone("11"), MustParseTone("35")
+	stem := Attach(morph.NewStem("mana"), high, low)
+	prefix := Attach(morph.NewMorpheme("ba", false, true), rising)
+	suffix := Attach(morph.NewMorpheme("ni", false, false), nil)
+
+	for _, testCase := range []struct {
+		Morpheme morph.Morpheme
+		Output   string
+		Tier     string
+	}{
+		{stem.Combine(suffix), "manani", "55 11 + 0"},
+		{suffix.Combine(stem), "manani", "55 11 + 0"},
+		{prefix.Combine(stem), "bamana", "35 + 55 11"},
+		{Combine(morph.NewStem("mana"), prefix), "bamana", "35"},
+		{prefix.Combine(stem).Combine(suffix), "bamanani", "35 + 55 11 + 0"},
+		{Combine(stem, stem), "manamana", "55 11 + 55 11"},
+	} {
+		if testCase.Morpheme.String() != testCase.Output || Of(testCase.Morpheme).String() != testCase.Tier {
+			t.Logf("Expected %q with tones %q; got %q with %q\n", testCase.Output, testCase.Tier, testCase.Morpheme, Of(testCase.Morpheme))
+			t.Fail()
+		}
+	}
+	if Of(morph.NewStem("mana")) != nil {
+		t.Logf("Expected no tones on a plain Morpheme\n")
+		t.Fail()
+	}
+}
+
+func TestOrthography(t *testing.T) {
+	a := alphabet.New([]alphabet.Letter{
+		alphabet.NewLetter("A", "a", 'V'),
+		alphabet.NewLetter("A\u0301", "a\u0301", 'V'),
+		alphabet.NewLetter("I", "i", 'V'),
+		alphabet.NewLetter("M", "m", 'C'),
+		alphabet.NewLetter("N", "n", 'C'),
+	})
+	s, err := syllabify.New(a, syllabify.Principles{MaximalOnset: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	syllables, err := s.SyllabifyWord("Manani")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tier := NewTier(MustParseTone("55"), MustParseTone("214"), nil)
+
+	o := Orthography{}
+	if output := o.Diacritics(a, syllables, tier); output != "Ma\u0301na\u030Cni" {
+		t.Logf("Expected diacritics %q; got %q\n", "Ma\u0301na\u030Cni", output)
+		t.Fail()
+	}
+	o = Orthography{Marks: map[string]string{"55": Macron}, Numbers: map[string]string{"55": "1", "214": "3"}}
+	if output := o.Diacritics(a, syllables, tier); output != "Ma\u0304na\u030Cni" {
+		t.Logf("Expected diacritics %q; got %q\n", "Ma\u0304na\u030Cni", output)
+		t.Fail()
+	}
+	if output := o.Numbered(syllables, tier, "-"); output != "Ma1-na3-ni" {
+		t.Logf("Expected numbers %q; got %q\n", "Ma1-na3-ni", output)
+		t.Fail()
+	}
+}
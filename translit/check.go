@@ -0,0 +1,106 @@
+package translit
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// IssueKind classifies a problem found by Check.
+type IssueKind int
+
+const (
+	// Lossy means several Rules produce the same target Letters, so Backward
+	// always restores the first of them.
+	Lossy IssueKind = iota + 1
+	// UnmappedSource means a source Letter is not the start of any Rule, so
+	// Forward fails on text containing it.
+	UnmappedSource
+	// UnmappedTarget means a target Letter is not the start of any Rule's To
+	// sequence, so Backward fails on text containing it.
+	UnmappedTarget
+	// OneWay means a Rule has a Class context, which Backward ignores, so it
+	// converts back even where it would not have applied forwards.
+	OneWay
+)
+
+// Issue describes a mapping that cannot be converted, or cannot be converted
+// back to where it started.
+type Issue struct {
+	Kind IssueKind
+	// Rules holds the Rules involved in a Lossy or OneWay Issue.
+	Rules []Rule
+	// Letter holds the Letter of an UnmappedSource or UnmappedTarget Issue.
+	Letter alphabet.Letter
+}
+
+func (i Issue) String() string {
+	switch i.Kind {
+	case Lossy:
+		rules := make([]string, len(i.Rules))
+		for j, rule := range i.Rules {
+			rules[j] = rule.String()
+		}
+		return fmt.Sprintf("lossy: %s are not reversible", strings.Join(rules, ", "))
+	case UnmappedSource:
+		return fmt.Sprintf("unmapped source letter %q", i.Letter.Lower())
+	case UnmappedTarget:
+		return fmt.Sprintf("unmapped target letter %q", i.Letter.Lower())
+	case OneWay:
+		return fmt.Sprintf("one way: %s ignores its context backwards", i.Rules[0])
+	}
+	return "???"
+}
+
+// Check reports lossy Rules, Rules whose context only applies forwards, and
+// Letters of either Alphabet that no Rule converts.
+func (t *Transliterator) Check() (issues []Issue) {
+	byTarget := map[string][]Rule{}
+	targets := []string{}
+	for _, rule := range t.rules {
+		to := lettersToString(rule.To)
+		if _, ok := byTarget[to]; !ok {
+			targets = append(targets, to)
+		}
+		byTarget[to] = append(byTarget[to], rule)
+	}
+	for _, to := range targets {
+		if rules := byTarget[to]; len(rules) > 1 {
+			issues = append(issues, Issue{Kind: Lossy, Rules: rules})
+		}
+	}
+
+	for _, rule := range t.rules {
+		if isClassContext(rule.Before) || isClassContext(rule.After) {
+			issues = append(issues, Issue{Kind: OneWay, Rules: []Rule{rule}})
+		}
+	}
+
+	starts := func(side func(Rule) []alphabet.Letter) map[string]bool {
+		set := map[string]bool{}
+		for _, rule := range t.rules {
+			if letters := side(rule); len(letters) > 0 {
+				set[letters[0].Lower()] = true
+			}
+		}
+		return set
+	}
+	sourceStarts := starts(func(r Rule) []alphabet.Letter { return r.From })
+	for _, letter := range t.source.GetLetters().ToSlice() {
+		if !sourceStarts[letter.Lower()] {
+			issues = append(issues, Issue{Kind: UnmappedSource, Letter: letter})
+		}
+	}
+	targetStarts := starts(func(r Rule) []alphabet.Letter { return r.To })
+	for _, letter := range t.target.GetLetters().ToSlice() {
+		if !targetStarts[letter.Lower()] {
+			issues = append(issues, Issue{Kind: UnmappedTarget, Letter: letter})
+		}
+	}
+	return
+}
+
+func isClassContext(c alphabet.Class) bool {
+	return c != 0 && c != alphabet.Boundary
+}
@@ -0,0 +1,195 @@
+// Package translit converts text between two Alphabets, such as a native script
+// and its romanization, one Letter at a time.
+package translit
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+// Rule maps a sequence of Letters from the source Alphabet to a sequence of
+// Letters in the target Alphabet. Either side may hold more than one Letter.
+type Rule struct {
+	From []alphabet.Letter
+	To   []alphabet.Letter
+	// Before and After restrict the Rule to From sequences preceded or followed
+	// by a Letter of the given Class. alphabet.Boundary matches the edge of a
+	// word and the zero Class matches anything. Backward conversions keep a
+	// Boundary context but ignore a Class context, since the Class belongs to
+	// the source Alphabet; Check reports such Rules as OneWay.
+	Before alphabet.Class
+	After  alphabet.Class
+}
+
+// NewRule makes a context-free Rule by tokenizing from in the source Alphabet
+// and to in the target Alphabet. Ambiguous strings use the longest-match
+// segmentation, just as conversions do.
+func NewRule(source, target alphabet.Alphabet, from, to string) (Rule, error) {
+	var ambiguous *alphabet.AmbiguousError
+	fromLetters, err := source.Tokenize(from)
+	if err != nil && !errors.As(err, &ambiguous) {
+		return Rule{}, err
+	}
+	toLetters, err := target.Tokenize(to)
+	if err != nil && !errors.As(err, &ambiguous) {
+		return Rule{}, err
+	}
+	return Rule{From: fromLetters, To: toLetters}, nil
+}
+
+func (r Rule) String() string {
+	return fmt.Sprintf("%s > %s", lettersToString(r.From), lettersToString(r.To))
+}
+
+// Error is returned when a Letter cannot be converted by any Rule.
+type Error struct {
+	Input  string
+	Offset int
+	Text   string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("translit: no rule for %q at byte offset %d of %q", e.Text, e.Offset, e.Input)
+}
+
+// Transliterator converts text between a source and a target Alphabet.
+type Transliterator struct {
+	source   alphabet.Alphabet
+	target   alphabet.Alphabet
+	rules    []Rule
+	forward  []directedRule
+	backward []directedRule
+}
+
+// directedRule is a Rule prepared for conversion in one direction.
+type directedRule struct {
+	from, to      []alphabet.Letter
+	before, after alphabet.Class
+}
+
+// New makes a Transliterator from source to target. Rules are tried longest
+// From sequence first; among Rules of the same length, the first one given
+// wins. Backward conversions use the first Rule whose To sequence matches.
+func New(source, target alphabet.Alphabet, rules ...Rule) *Transliterator {
+	t := &Transliterator{source: source, target: target, rules: rules}
+	for _, rule := range rules {
+		t.forward = append(t.forward, directedRule{rule.From, rule.To, rule.Before, rule.After})
+		backward := directedRule{from: rule.To, to: rule.From}
+		if rule.Before == alphabet.Boundary {
+			backward.before = rule.Before
+		}
+		if rule.After == alphabet.Boundary {
+			backward.after = rule.After
+		}
+		t.backward = append(t.backward, backward)
+	}
+	longestFirst := func(a, b directedRule) int { return len(b.from) - len(a.from) }
+	slices.SortStableFunc(t.forward, longestFirst)
+	slices.SortStableFunc(t.backward, longestFirst)
+	return t
+}
+
+// Rules returns the Rules the Transliterator was made with.
+func (t *Transliterator) Rules() []Rule { return t.rules }
+
+// Forward converts text written in the source Alphabet to the target Alphabet.
+// Text that is not part of the source Alphabet, such as spaces and
+// punctuation, is copied as is and separates words.
+func (t *Transliterator) Forward(s string) (string, error) {
+	return convert(t.source, t.target, t.forward, s)
+}
+
+// Backward converts text written in the target Alphabet back to the source
+// Alphabet. Rules only apply at word boundaries if they do so forwards, but
+// other contexts are ignored. Call Check to find out where this may not
+// restore the original.
+func (t *Transliterator) Backward(s string) (string, error) {
+	return convert(t.target, t.source, t.backward, s)
+}
+
+func convert(in, out alphabet.Alphabet, rules []directedRule, s string) (string, error) {
+	var b strings.Builder
+	segments := in.Segment(s)
+	for i := 0; i < len(segments); {
+		if segments[i].Letter == nil {
+			b.WriteString(segments[i].Text)
+			i++
+			continue
+		}
+
+		rule, ok := findRule(in, rules, segments, i)
+		if !ok {
+			return "", &Error{Input: s, Offset: segments[i].Offset, Text: segments[i].Text}
+		}
+
+		chunk := lettersToString(rule.to)
+		if first := segments[i]; first.IsUpper() {
+			if isShouting(in, segments, i, i+len(rule.from)) {
+				chunk = alphabet.ToUpper(out, chunk)
+			} else {
+				chunk = alphabet.ToTitle(out, chunk)
+			}
+		}
+		b.WriteString(chunk)
+		i += len(rule.from)
+	}
+	return b.String(), nil
+}
+
+// findRule returns the first rule whose from sequence and context match the
+// segments starting at i.
+func findRule(in alphabet.Alphabet, rules []directedRule, segments []alphabet.Segment, i int) (directedRule, bool) {
+	for _, rule := range rules {
+		end := i + len(rule.from)
+		if len(rule.from) == 0 || end > len(segments) {
+			continue
+		}
+		matched := true
+		for j, letter := range rule.from {
+			if segments[i+j].Letter == nil || segments[i+j].Letter.Lower() != letter.Lower() {
+				matched = false
+				break
+			}
+		}
+		if matched && contextMatches(in, rule.before, segments, i-1) && contextMatches(in, rule.after, segments, end) {
+			return rule, true
+		}
+	}
+	return directedRule{}, false
+}
+
+// isShouting decides whether the uppercase Letter at segments[start] belongs
+// to an all caps word, looking at its neighbours when it is a single rune.
+func isShouting(in alphabet.Alphabet, segments []alphabet.Segment, start, end int) bool {
+	first := segments[start].Text
+	if first != alphabet.ToUpper(in, first) {
+		return false
+	}
+	if len([]rune(first)) > 1 {
+		return true
+	}
+	return start > 0 && segments[start-1].IsUpper() || end < len(segments) && segments[end].IsUpper()
+}
+
+func contextMatches(in alphabet.Alphabet, class alphabet.Class, segments []alphabet.Segment, i int) bool {
+	if class == 0 {
+		return true
+	}
+	atBoundary := i < 0 || i >= len(segments) || segments[i].Letter == nil
+	if class == alphabet.Boundary {
+		return atBoundary
+	}
+	return !atBoundary && in.HasClass(segments[i].Letter, class)
+}
+
+func lettersToString(letters []alphabet.Letter) string {
+	var b strings.Builder
+	for _, letter := range letters {
+		b.WriteString(letter.Lower())
+	}
+	return b.String()
+}
@@ -0,0 +1,138 @@
+package translit
+
+import (
+	"testing"
+
+	"github.com/jack-reeser/conlang/alphabet"
+)
+
+func TestTransliterate(t *testing.T) {
+	native := alphabet.New([]alphabet.Letter{
+		alphabet.NewLetter("А", "а", 'V'),
+		alphabet.NewLetter("Е", "е", 'V'),
+		alphabet.NewLetter("Э", "э", 'V'),
+		alphabet.NewLetter("Ш", "ш", 'C'),
+		alphabet.NewLetter("Щ", "щ", 'C'),
+		alphabet.NewLetter("Ч", "ч", 'C'),
+		alphabet.NewLetter("Т", "т", 'C'),
+	})
+	roman := alphabet.New([]alphabet.Letter{
+		alphabet.NewLetter("A", "a", 'V'),
+		alphabet.NewLetter("E", "e", 'V'),
+		alphabet.NewLetter("Y", "y", 'C'),
+		alphabet.NewLetter("SH", "sh", 'C'),
+		alphabet.NewLetter("CH", "ch", 'C'),
+		alphabet.NewLetter("T", "t", 'C'),
+		alphabet.NewLetter("Z", "z", 'C'),
+	})
+
+	rule := func(from, to string) Rule {
+		r, err := NewRule(native, roman, from, to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return r
+	}
+	initialYe := rule("е", "ye")
+	initialYe.Before = alphabet.Boundary
+
+	tr := New(native, roman,
+		rule("а", "a"),
+		initialYe,
+		rule("е", "e"),
+		rule("э", "e"),
+		rule("ш", "sh"),
+		rule("щ", "shch"),
+		rule("ч", "ch"),
+		rule("т", "t"),
+	)
+
+	for _, testCase := range []struct {
+		Native string
+		Roman  string
+	}{
+		{"щет", "shchet"},
+		{"Еш тэт", "Yesh tet"},
+		{"ШАЧ", "SHACH"},
+	} {
+		roman, err := tr.Forward(testCase.Native)
+		if err != nil {
+			t.Log(err)
+			t.Fail()
+			continue
+		}
+		if roman != testCase.Roman {
+			t.Logf("Expected %q to transliterate to %q; got %q\n", testCase.Native, testCase.Roman, roman)
+			t.Fail()
+		}
+	}
+
+	if _, err := tr.Backward("yex"); err != nil {
+		t.Logf("Expected unknown text to pass through; got %v\n", err)
+		t.Fail()
+	}
+	if back, err := tr.Backward("Yesh tet"); err != nil || back != "Еш тет" {
+		t.Logf("Expected \"Yesh tet\" to transliterate back to \"Еш тет\"; got %q (%v)\n", back, err)
+		t.Fail()
+	}
+
+	// word boundary contexts hold backwards too
+	if _, err := tr.Backward("tye"); err == nil {
+		t.Logf("Expected medial \"ye\" not to transliterate back\n")
+		t.Fail()
+	}
+
+	lossy, unmapped := 0, 0
+	for _, issue := range tr.Check() {
+		t.Log(issue)
+		switch issue.Kind {
+		case Lossy:
+			lossy++
+		case UnmappedTarget:
+			unmapped++
+		}
+	}
+	if lossy != 1 || unmapped != 1 {
+		t.Logf("Expected 1 lossy mapping and 1 unmapped target letter; got %d and %d\n", lossy, unmapped)
+		t.Fail()
+	}
+}
+
+func TestContext(t *testing.T) {
+	native := alphabet.New([]alphabet.Letter{
+		alphabet.NewLetter("А", "а", 'V'),
+		alphabet.NewLetter("Т", "т", 'C'),
+	})
+	roman := alphabet.New([]alphabet.Letter{
+		alphabet.NewLetter("A", "a", 'V'),
+		alphabet.NewLetter("CH", "ch", 'C'),
+		alphabet.NewLetter("T", "t", 'C'),
+	})
+	palatal := Rule{From: native.GetLettersByClass('C').ToSlice(), To: roman.GetLettersByClass('C').ToSlice()[:1], After: 'V'}
+	tr := New(native, roman,
+		palatal,
+		Rule{From: native.GetLettersByClass('C').ToSlice(), To: roman.GetLettersByClass('C').ToSlice()[1:]},
+		Rule{From: native.GetLettersByClass('V').ToSlice(), To: roman.GetLettersByClass('V').ToSlice()},
+	)
+
+	if forward, err := tr.Forward("тат"); err != nil || forward != "chat" {
+		t.Logf("Expected \"тат\" to transliterate to \"chat\"; got %q (%v)\n", forward, err)
+		t.Fail()
+	}
+	// the class context is ignored backwards, so "ch" comes back as "т" even
+	// before a consonant, and "тт" does not round-trip to "cht"
+	if back, err := tr.Backward("cht"); err != nil || back != "тт" {
+		t.Logf("Expected \"cht\" to transliterate back to \"тт\"; got %q (%v)\n", back, err)
+		t.Fail()
+	}
+	if forward, _ := tr.Forward("тт"); forward == "cht" {
+		t.Logf("Expected \"тт\" not to round-trip to \"cht\"\n")
+		t.Fail()
+	}
+
+	issues := tr.Check()
+	if len(issues) != 1 || issues[0].Kind != OneWay || issues[0].Rules[0].String() != palatal.String() {
+		t.Logf("Expected only the palatal rule to be reported as one way; got %v\n", issues)
+		t.Fail()
+	}
+}